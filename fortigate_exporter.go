@@ -31,11 +31,15 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"syscall"
 
 	"github.com/prometheus-community/fortigate_exporter/pkg/probe"
 
@@ -51,6 +55,17 @@ var (
 	GitHash = "(no hash)"
 )
 
+var (
+	configReloadSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "fortigate_exporter_config_last_reload_successful",
+		Help: "Whether the last configuration reload attempt was successful",
+	})
+	configReloadSeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "fortigate_exporter_config_last_reload_success_timestamp_seconds",
+		Help: "Timestamp of the last successful configuration reload",
+	})
+)
+
 type BuildInfo struct {
 	version   string
 	gitHash   string
@@ -89,6 +104,65 @@ func getBuildInfo() BuildInfo {
 	return buildInfo
 }
 
+// reloadConfig re-reads the flags and the auth file, on failure the previous
+// configuration stays in place
+func reloadConfig() error {
+	newConfig, err := config.Load()
+	if err != nil {
+		configReloadSuccess.Set(0)
+		return err
+	}
+	// Configure checks the TLS settings before anything is changed, so the new
+	// configuration only goes live once they are known to be valid
+	if err := fortiHTTP.Configure(*newConfig); err != nil {
+		configReloadSuccess.Set(0)
+		return err
+	}
+	config.Apply(newConfig)
+	configReloadSuccess.Set(1)
+	configReloadSeconds.SetToCurrentTime()
+	return nil
+}
+
+// handleReloads serializes reloads triggered by SIGHUP and by the /-/reload endpoint
+func handleReloads(reloadCh chan chan error) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for {
+		select {
+		case <-hup:
+			logReload(reloadConfig())
+		case rc := <-reloadCh:
+			err := reloadConfig()
+			logReload(err)
+			rc <- err
+		}
+	}
+}
+
+func logReload(err error) {
+	if err != nil {
+		log.Printf("Error: configuration reload failed, keeping previous configuration: %v", err)
+		return
+	}
+	log.Printf("Configuration reloaded")
+}
+
+func reloadHandler(reloadCh chan chan error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Only POST requests allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rc := make(chan error)
+		reloadCh <- rc
+		if err := <-rc; err != nil {
+			http.Error(w, fmt.Sprintf("failed to reload config: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
 func main() {
 	buildInfo := getBuildInfo()
 	log.Printf("FortigateExporter %s ( %s )", buildInfo.version, buildInfo.gitHash)
@@ -99,13 +173,22 @@ func main() {
 	}
 
 	savedConfig := config.GetConfig()
+	config.GetDynamicTargets().OnEvict(probe.ForgetTarget)
 
 	if err := fortiHTTP.Configure(savedConfig); err != nil {
 		log.Fatalf("%+v", err)
 	}
+	configReloadSuccess.Set(1)
+	configReloadSeconds.SetToCurrentTime()
+
+	reloadCh := make(chan chan error)
+	go handleReloads(reloadCh)
 
 	http.Handle("/metrics", promhttp.Handler())
 	http.HandleFunc("/probe", probe.ProbeHandler)
+	if savedConfig.EnableLifecycle {
+		http.HandleFunc("/-/reload", reloadHandler(reloadCh))
+	}
 	go func() {
 		if err := http.ListenAndServe(savedConfig.Listen, nil); err != nil {
 			log.Fatalf("Unable to serve: %v", err)
@@ -0,0 +1,79 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestReloadHandler(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "fortigate-key.yaml")
+	if err := os.WriteFile(p, []byte("\"https://fortigate-old\":\n  token: old-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := flag.Set("auth-file", p); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.ReInit(); err != nil {
+		t.Fatalf("ReInit() failed: %v", err)
+	}
+
+	reloadCh := make(chan chan error)
+	go handleReloads(reloadCh)
+	h := reloadHandler(reloadCh)
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/-/reload", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /-/reload returned %d, expected %d", w.Code, http.StatusMethodNotAllowed)
+	}
+
+	if err := os.WriteFile(p, []byte("\"https://fortigate-new\":\n  token: new-token\n  token_file: /does/not/exist\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/-/reload", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("POST /-/reload with an invalid auth file returned %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+	if _, ok := config.GetConfig().AuthKeys["https://fortigate-old"]; !ok {
+		t.Errorf("failed reload replaced the configuration")
+	}
+	if v := testutil.ToFloat64(configReloadSuccess); v != 0 {
+		t.Errorf("fortigate_exporter_config_last_reload_successful is %v after a failed reload, expected 0", v)
+	}
+
+	if err := os.WriteFile(p, []byte("\"https://fortigate-new\":\n  token: new-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/-/reload", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("POST /-/reload returned %d, expected %d", w.Code, http.StatusOK)
+	}
+	if _, ok := config.GetConfig().AuthKeys["https://fortigate-new"]; !ok {
+		t.Errorf("reload did not apply the new configuration")
+	}
+	if v := testutil.ToFloat64(configReloadSuccess); v != 1 {
+		t.Errorf("fortigate_exporter_config_last_reload_successful is %v after a successful reload, expected 1", v)
+	}
+}
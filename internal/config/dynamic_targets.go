@@ -0,0 +1,133 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+// DynamicTargets holds the targets registered through the token= probe parameter.
+// Entries expire when they have not been used for the TTL, and the least
+// recently used entry is evicted when the store is full.
+type DynamicTargets struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	max     int
+	now     func() time.Time
+	targets map[Target]*dynamicTarget
+	// onEvict is called for every target that is forgotten
+	onEvict func(Target)
+}
+
+type dynamicTarget struct {
+	auth     TargetAuth
+	lastSeen time.Time
+}
+
+// NewDynamicTargets returns an empty store, a ttl or max of 0 means no limit
+func NewDynamicTargets(ttl time.Duration, max int) *DynamicTargets {
+	return &DynamicTargets{
+		ttl:     ttl,
+		max:     max,
+		now:     time.Now,
+		targets: map[Target]*dynamicTarget{},
+	}
+}
+
+// SetLimits changes the TTL and the maximum number of entries
+func (d *DynamicTargets) SetLimits(ttl time.Duration, max int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ttl = ttl
+	d.max = max
+	d.evict()
+}
+
+// OnEvict sets a function called with every target that expires or is
+// evicted, so state kept per target elsewhere can be dropped with it
+func (d *DynamicTargets) OnEvict(f func(Target)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onEvict = f
+}
+
+// Put adds or updates a target
+func (d *DynamicTargets) Put(t Target, auth TargetAuth) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if e, ok := d.targets[t]; ok {
+		e.auth = auth
+		e.lastSeen = d.now()
+		return
+	}
+	d.targets[t] = &dynamicTarget{auth: auth, lastSeen: d.now()}
+	d.evict()
+}
+
+// Get returns a target that has not expired yet
+func (d *DynamicTargets) Get(t Target) (TargetAuth, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	e, ok := d.targets[t]
+	if !ok {
+		return TargetAuth{}, false
+	}
+	if d.expired(e) {
+		d.forget(t)
+		return TargetAuth{}, false
+	}
+	e.lastSeen = d.now()
+	return e.auth, true
+}
+
+// Len returns the number of entries, including those not evicted yet
+func (d *DynamicTargets) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.targets)
+}
+
+func (d *DynamicTargets) expired(e *dynamicTarget) bool {
+	return d.ttl > 0 && d.now().Sub(e.lastSeen) > d.ttl
+}
+
+// evict must be called with d.mu held
+func (d *DynamicTargets) evict() {
+	for t, e := range d.targets {
+		if d.expired(e) {
+			d.forget(t)
+		}
+	}
+	for d.max > 0 && len(d.targets) > d.max {
+		var oldest Target
+		var oldestSeen time.Time
+		for t, e := range d.targets {
+			if oldestSeen.IsZero() || e.lastSeen.Before(oldestSeen) {
+				oldest, oldestSeen = t, e.lastSeen
+			}
+		}
+		d.forget(oldest)
+	}
+}
+
+// forget must be called with d.mu held
+func (d *DynamicTargets) forget(t Target) {
+	delete(d.targets, t)
+	if d.onEvict != nil {
+		d.onEvict(t)
+	}
+}
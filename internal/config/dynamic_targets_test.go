@@ -0,0 +1,142 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func newTestDynamicTargets(ttl time.Duration, max int) (*DynamicTargets, *fakeClock) {
+	clk := &fakeClock{time.Unix(1600000000, 0)}
+	d := NewDynamicTargets(ttl, max)
+	d.now = clk.now
+	return d, clk
+}
+
+func TestDynamicTargetsUpdate(t *testing.T) {
+	d, _ := newTestDynamicTargets(time.Hour, 10)
+
+	d.Put("https://a", TargetAuth{Token: "first"})
+	d.Put("https://a", TargetAuth{Token: "second"})
+	if a, ok := d.Get("https://a"); !ok || a.Token != "second" {
+		t.Errorf("Get() = %v, %v, expected token \"second\"", a, ok)
+	}
+	if _, ok := d.Get("https://b"); ok {
+		t.Errorf("Get() of unknown target succeeded")
+	}
+}
+
+func TestDynamicTargetsTTL(t *testing.T) {
+	d, clk := newTestDynamicTargets(time.Hour, 10)
+
+	d.Put("https://a", TargetAuth{Token: "a"})
+	d.Put("https://b", TargetAuth{Token: "b"})
+
+	clk.t = clk.t.Add(45 * time.Minute)
+	if _, ok := d.Get("https://a"); !ok {
+		t.Fatalf("Get() of target within TTL failed")
+	}
+
+	clk.t = clk.t.Add(30 * time.Minute)
+	if _, ok := d.Get("https://a"); !ok {
+		t.Errorf("Get() of recently used target failed")
+	}
+	if _, ok := d.Get("https://b"); ok {
+		t.Errorf("Get() of expired target succeeded")
+	}
+
+	clk.t = clk.t.Add(2 * time.Hour)
+	d.Put("https://c", TargetAuth{Token: "c"})
+	if d.Len() != 1 {
+		t.Errorf("Len() = %d after eviction, expected 1", d.Len())
+	}
+}
+
+func TestDynamicTargetsMax(t *testing.T) {
+	d, clk := newTestDynamicTargets(0, 2)
+
+	for _, tgt := range []Target{"https://a", "https://b"} {
+		d.Put(tgt, TargetAuth{})
+		clk.t = clk.t.Add(time.Second)
+	}
+	// Touch a so b is the least recently used
+	d.Get("https://a")
+	clk.t = clk.t.Add(time.Second)
+	d.Put("https://c", TargetAuth{})
+
+	if d.Len() != 2 {
+		t.Errorf("Len() = %d, expected 2", d.Len())
+	}
+	if _, ok := d.Get("https://b"); ok {
+		t.Errorf("least recently used target was not evicted")
+	}
+	for _, tgt := range []Target{"https://a", "https://c"} {
+		if _, ok := d.Get(tgt); !ok {
+			t.Errorf("Get(%q) failed", tgt)
+		}
+	}
+
+	d.SetLimits(0, 1)
+	if d.Len() != 1 {
+		t.Errorf("Len() = %d after lowering the limit, expected 1", d.Len())
+	}
+}
+
+func TestDynamicTargetsConcurrent(t *testing.T) {
+	d := NewDynamicTargets(time.Hour, 50)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				tgt := Target(fmt.Sprintf("https://fgt-%d", j))
+				d.Put(tgt, TargetAuth{Token: Token(fmt.Sprintf("%d", i))})
+				d.Get(tgt)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if d.Len() > 50 {
+		t.Errorf("Len() = %d, expected at most 50", d.Len())
+	}
+}
+
+func TestDynamicTargetsOnEvict(t *testing.T) {
+	d, clk := newTestDynamicTargets(time.Hour, 1)
+	var evicted []Target
+	d.OnEvict(func(t Target) { evicted = append(evicted, t) })
+
+	d.Put("https://a", TargetAuth{})
+	clk.t = clk.t.Add(time.Second)
+	d.Put("https://b", TargetAuth{})
+	clk.t = clk.t.Add(2 * time.Hour)
+	d.Get("https://b")
+
+	if len(evicted) != 2 || evicted[0] != "https://a" || evicted[1] != "https://b" {
+		t.Errorf("OnEvict() was called with %v, expected [https://a https://b]", evicted)
+	}
+}
@@ -15,33 +15,57 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
 type FortiExporterParameter struct {
-	AuthFile      *string
-	Listen        *string
-	ScrapeTimeout *int
-	TLSTimeout    *int
-	TLSInsecure   *bool
-	TlsExtraCAs   *string
-	MaxBGPPaths   *int
-	MaxVPNUsers   *int
+	AuthFile         *string
+	Listen           *string
+	EnableLifecycle  *bool
+	ScrapeTimeout    *int
+	TLSTimeout       *int
+	TLSInsecure      *bool
+	TlsExtraCAs      *string
+	MaxBGPPaths      *int
+	BGPSkipPaths     *bool
+	MaxVPNUsers      *int
+	VPNUserDetails   *bool
+	HAMembers        *bool
+	HAMemberProbes   *string
+	HAMemberParam    *string
+	ProbeConcurrency *int
+	AllowDynamic     *bool
+	DynamicTTL       *int
+	MaxDynamic       *int
 }
 
 type FortiExporterConfig struct {
-	AuthKeys      AuthKeys
-	Listen        string
-	ScrapeTimeout int
-	TLSTimeout    int
-	TLSInsecure   bool
-	TlsExtraCAs   []LocalCert
-	MaxBGPPaths   int
-	MaxVPNUsers   int
+	AuthKeys         AuthKeys
+	Modules          Modules
+	Listen           string
+	EnableLifecycle  bool
+	ScrapeTimeout    int
+	TLSTimeout       int
+	TLSInsecure      bool
+	TlsExtraCAs      []LocalCert
+	MaxBGPPaths      int
+	BGPSkipPaths     bool
+	MaxVPNUsers      int
+	VPNUserDetails   bool
+	HAMembers        bool
+	HAMemberProbes   ProbeList
+	HAMemberParam    string
+	ProbeConcurrency int
+	AllowDynamic     bool
+	DynamicTTL       int
+	MaxDynamic       int
 }
 
 type AuthKeys map[Target]TargetAuth
@@ -55,9 +79,50 @@ type Probes struct {
 	Exclude ProbeList
 }
 
-type TargetAuth struct {
-	Token  Token
+// Module is a named set of probes and options that can be selected with the
+// module= probe parameter
+type Module struct {
 	Probes Probes
+	// Timeout in seconds, overrides -scrape-timeout if set
+	Timeout int
+	// MaxBGPPaths overrides -max-bgp-paths if set
+	MaxBGPPaths int `yaml:"max_bgp_paths"`
+	// BGPSkipPaths enables skipping the BGP paths download if set
+	BGPSkipPaths bool `yaml:"bgp_skip_paths"`
+	// MaxVPNUsers overrides -max-vpn-users if set
+	MaxVPNUsers int `yaml:"max_vpn_users"`
+	// VPNUserDetails enables the per session SSL-VPN metrics if set
+	VPNUserDetails bool `yaml:"vpn_user_details"`
+}
+
+type Modules map[string]Module
+
+// authFile is the layout of the auth file, every top level key except
+// "modules" is a target
+type authFile struct {
+	Modules Modules               `yaml:"modules"`
+	Targets map[string]TargetAuth `yaml:",inline"`
+}
+
+type TargetAuth struct {
+	Token       Token
+	TokenFile   string `yaml:"token_file"`
+	TokenEnv    string `yaml:"token_env"`
+	Username    string
+	Password    string
+	Probes      Probes
+	Concurrency int
+	TLS         TLSConfig `yaml:"tls"`
+}
+
+// TLSConfig holds the per target TLS settings, they are applied on top of
+// -insecure and -extra-ca-certs
+type TLSConfig struct {
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+	CAFile             string `yaml:"ca_file"`
+	ServerName         string `yaml:"server_name"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
 }
 
 type LocalCert struct {
@@ -67,22 +132,39 @@ type LocalCert struct {
 
 var (
 	parameter = FortiExporterParameter{
-		AuthFile:      flag.String("auth-file", "fortigate-key.yaml", "file containing the authentication map to use when connecting to a Fortigate device"),
-		Listen:        flag.String("listen", ":9710", "address to listen on"),
-		ScrapeTimeout: flag.Int("scrape-timeout", 30, "max seconds to allow a scrape to take"),
-		TLSTimeout:    flag.Int("https-timeout", 10, "TLS Handshake timeout in seconds"),
-		TLSInsecure:   flag.Bool("insecure", false, "Allow insecure certificates"),
-		TlsExtraCAs:   flag.String("extra-ca-certs", "", "comma-separated files containing extra PEMs to trust for TLS connections in addition to the system trust store"),
-		MaxBGPPaths:   flag.Int("max-bgp-paths", 10000, "How many BGP Paths to receive when counting routes, needs to be greater than or equal to the number of routes or metrics will not be generated"),
-		MaxVPNUsers:   flag.Int("max-vpn-users", 0, "How many VPN Users to receive when counting users, needs to be greater than or equal the number of users or metrics will not be generated (0 eq. none by default)"),
+		AuthFile:         flag.String("auth-file", "fortigate-key.yaml", "file containing the authentication map to use when connecting to a Fortigate device"),
+		Listen:           flag.String("listen", ":9710", "address to listen on"),
+		EnableLifecycle:  flag.Bool("enable-lifecycle", false, "Enable reloading the configuration through a POST request to /-/reload"),
+		ScrapeTimeout:    flag.Int("scrape-timeout", 30, "max seconds to allow a scrape to take"),
+		TLSTimeout:       flag.Int("https-timeout", 10, "TLS Handshake timeout in seconds"),
+		TLSInsecure:      flag.Bool("insecure", false, "Allow insecure certificates"),
+		TlsExtraCAs:      flag.String("extra-ca-certs", "", "comma-separated files containing extra PEMs to trust for TLS connections in addition to the system trust store"),
+		MaxBGPPaths:      flag.Int("max-bgp-paths", 10000, "How many BGP paths to count per VDOM, any further paths are left out and reported as truncated"),
+		BGPSkipPaths:     flag.Bool("bgp-skip-paths", false, "Skip downloading the BGP paths when every neighbor reports its accepted prefixes, the path counts are then not exported"),
+		MaxVPNUsers:      flag.Int("max-vpn-users", 0, "How many VPN Users to receive when counting users, needs to be greater than or equal the number of users or metrics will not be generated (0 eq. none by default)"),
+		VPNUserDetails:   flag.Bool("vpn-user-details", false, "Export per session SSL-VPN metrics, limited to -max-vpn-users sessions per VDOM"),
+		HAMembers:        flag.Bool("ha-members", false, "Run the -ha-member-probes against every member of an HA cluster, labeled with ha_serial"),
+		HAMemberProbes:   flag.String("ha-member-probes", "System/SensorInfo,System/Resource/Usage,System/Interface,System/Time/Clock", "comma-separated probe prefixes to run against every HA cluster member when -ha-members is set"),
+		HAMemberParam:    flag.String("ha-member-param", "serial", "query parameter that makes the primary forward a request to the HA cluster member with the given serial"),
+		ProbeConcurrency: flag.Int("probe-concurrency", 4, "How many probes to run in parallel against a single target, can be overridden per target in the auth file"),
+		AllowDynamic:     flag.Bool("allow-dynamic-targets", true, "Allow probing targets not in the auth file by passing a token parameter"),
+		DynamicTTL:       flag.Int("dynamic-target-ttl", 3600, "Seconds after which an unused dynamic target is forgotten (0 eq. never)"),
+		MaxDynamic:       flag.Int("max-dynamic-targets", 1000, "How many dynamic targets to remember, the least recently used one is forgotten first (0 eq. no limit)"),
 	}
 
-	savedConfig *FortiExporterConfig
+	dynamicTargets = NewDynamicTargets(0, 0)
+
+	// savedConfigMu guards savedConfig, which is swapped as a whole on reload
+	savedConfigMu sync.RWMutex
+	savedConfig   *FortiExporterConfig
 )
 
 func Init() error {
 	// check if already parsed
-	if savedConfig != nil {
+	savedConfigMu.RLock()
+	parsed := savedConfig != nil
+	savedConfigMu.RUnlock()
+	if parsed {
 		return nil
 	}
 	return ReInit()
@@ -93,31 +175,65 @@ func MustReInit() {
 		log.Fatalf("config.ReInit failed: %+v", err)
 	}
 }
+
+// ReInit parses the flags and the auth file again. The new configuration only
+// replaces the current one if it could be loaded completely.
 func ReInit() error {
+	newConfig, err := Load()
+	if err != nil {
+		return err
+	}
+	Apply(newConfig)
+	return nil
+}
+
+// Load parses the flags and the auth file into a new configuration without
+// making it the current one
+func Load() (*FortiExporterConfig, error) {
 	flag.Parse()
 
-	savedConfig = &FortiExporterConfig{
-		Listen:        *parameter.Listen,
-		ScrapeTimeout: *parameter.ScrapeTimeout,
-		TLSTimeout:    *parameter.TLSTimeout,
-		TLSInsecure:   *parameter.TLSInsecure,
-		MaxBGPPaths:   *parameter.MaxBGPPaths,
-		MaxVPNUsers:   *parameter.MaxVPNUsers,
+	newConfig := &FortiExporterConfig{
+		Listen:           *parameter.Listen,
+		EnableLifecycle:  *parameter.EnableLifecycle,
+		ScrapeTimeout:    *parameter.ScrapeTimeout,
+		TLSTimeout:       *parameter.TLSTimeout,
+		TLSInsecure:      *parameter.TLSInsecure,
+		MaxBGPPaths:      *parameter.MaxBGPPaths,
+		BGPSkipPaths:     *parameter.BGPSkipPaths,
+		MaxVPNUsers:      *parameter.MaxVPNUsers,
+		VPNUserDetails:   *parameter.VPNUserDetails,
+		HAMembers:        *parameter.HAMembers,
+		HAMemberParam:    *parameter.HAMemberParam,
+		ProbeConcurrency: *parameter.ProbeConcurrency,
+		AllowDynamic:     *parameter.AllowDynamic,
+		DynamicTTL:       *parameter.DynamicTTL,
+		MaxDynamic:       *parameter.MaxDynamic,
 	}
 
 	// parse AuthKeys
 	af, err := os.ReadFile(*parameter.AuthFile)
 	if err != nil {
-		log.Fatalf("Failed to read API authentication map file: %v", err)
-		return err
+		return nil, fmt.Errorf("failed to read API authentication map file: %w", err)
 	}
 
-	if err := yaml.Unmarshal(af, &savedConfig.AuthKeys); err != nil {
-		log.Fatalf("Failed to parse API authentication map file: %v", err)
-		return err
+	var f authFile
+	if err := yaml.Unmarshal(af, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse API authentication map file: %w", err)
 	}
+	newConfig.AuthKeys = AuthKeys{}
+	for t, a := range f.Targets {
+		if err := a.validate(); err != nil {
+			return nil, fmt.Errorf("invalid authentication for %q: %w", t, err)
+		}
+		newConfig.AuthKeys[Target(t)] = a
+	}
+	newConfig.Modules = f.Modules
 
-	log.Printf("Loaded %d API keys", len(savedConfig.AuthKeys))
+	for _, p := range strings.Split(*parameter.HAMemberProbes, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			newConfig.HAMemberProbes = append(newConfig.HAMemberProbes, p)
+		}
+	}
 
 	// parse ExtraCAs
 	for _, eca := range strings.Split(*parameter.TlsExtraCAs, ",") {
@@ -127,20 +243,37 @@ func ReInit() error {
 
 		certs, err := os.ReadFile(eca)
 		if err != nil {
-			log.Fatalf("Failed to read extra CA file %q: %v", eca, err)
-			return err
+			return nil, fmt.Errorf("failed to read extra CA file %q: %w", eca, err)
 		}
 
 		certObject := LocalCert{
 			Path:    eca,
 			Content: certs,
 		}
-		savedConfig.TlsExtraCAs = append(savedConfig.TlsExtraCAs, certObject)
+		newConfig.TlsExtraCAs = append(newConfig.TlsExtraCAs, certObject)
 	}
 
-	return nil
+	return newConfig, nil
+}
+
+// Apply makes the configuration the current one
+func Apply(newConfig *FortiExporterConfig) {
+	savedConfigMu.Lock()
+	savedConfig = newConfig
+	savedConfigMu.Unlock()
+
+	dynamicTargets.SetLimits(time.Duration(newConfig.DynamicTTL)*time.Second, newConfig.MaxDynamic)
+
+	log.Printf("Loaded %d API keys and %d modules", len(newConfig.AuthKeys), len(newConfig.Modules))
 }
 
 func GetConfig() FortiExporterConfig {
+	savedConfigMu.RLock()
+	defer savedConfigMu.RUnlock()
 	return *savedConfig
 }
+
+// GetDynamicTargets returns the store of targets registered through the token= probe parameter
+func GetDynamicTargets() *DynamicTargets {
+	return dynamicTargets
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeAuthFile(t *testing.T, content string) {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "fortigate-key.yaml")
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := flag.Set("auth-file", p); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReInitModules(t *testing.T) {
+	writeAuthFile(t, `
+modules:
+  core:
+    timeout: 10
+    probes:
+      include:
+        - System
+  bgp:
+    max_bgp_paths: 500000
+    probes:
+      include:
+        - BGP
+"https://my-fortigate":
+  token: api-key-goes-here
+`)
+	if err := ReInit(); err != nil {
+		t.Fatalf("ReInit() failed: %v", err)
+	}
+
+	c := GetConfig()
+	expModules := Modules{
+		"core": {Timeout: 10, Probes: Probes{Include: ProbeList{"System"}}},
+		"bgp":  {MaxBGPPaths: 500000, Probes: Probes{Include: ProbeList{"BGP"}}},
+	}
+	if !reflect.DeepEqual(c.Modules, expModules) {
+		t.Errorf("Modules = %+v, expected %+v", c.Modules, expModules)
+	}
+	expAuth := AuthKeys{"https://my-fortigate": {Token: "api-key-goes-here"}}
+	if !reflect.DeepEqual(c.AuthKeys, expAuth) {
+		t.Errorf("AuthKeys = %+v, expected %+v", c.AuthKeys, expAuth)
+	}
+}
+
+func TestReInitKeepsConfigOnError(t *testing.T) {
+	writeAuthFile(t, `
+"https://my-fortigate":
+  token: api-key-goes-here
+`)
+	if err := ReInit(); err != nil {
+		t.Fatalf("ReInit() failed: %v", err)
+	}
+
+	writeAuthFile(t, `"https://my-fortigate": [`)
+	if err := ReInit(); err == nil {
+		t.Fatalf("ReInit() of broken file succeeded")
+	}
+	if _, ok := GetConfig().AuthKeys["https://my-fortigate"]; !ok {
+		t.Errorf("previous configuration was not kept")
+	}
+}
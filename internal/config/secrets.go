@@ -0,0 +1,109 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenFile is a token read from disk, it is re-read when the file changes
+type tokenFile struct {
+	modTime time.Time
+	size    int64
+	token   Token
+}
+
+var (
+	tokenFilesMu sync.Mutex
+	tokenFiles   = map[string]tokenFile{}
+)
+
+func readTokenFile(path string) (Token, error) {
+	// Stat follows symlinks, so this also notices Kubernetes secret updates
+	// which swap the symlinked directory
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	tokenFilesMu.Lock()
+	defer tokenFilesMu.Unlock()
+
+	if tf, ok := tokenFiles[path]; ok && tf.modTime.Equal(fi.ModTime()) && tf.size == fi.Size() {
+		return tf.token, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	tok := Token(strings.TrimSpace(string(b)))
+	if tok == "" {
+		return "", fmt.Errorf("token file %q is empty", path)
+	}
+	tokenFiles[path] = tokenFile{fi.ModTime(), fi.Size(), tok}
+	return tok, nil
+}
+
+// HasToken reports whether token authentication is configured
+func (a TargetAuth) HasToken() bool {
+	return a.Token != "" || a.TokenFile != "" || a.TokenEnv != ""
+}
+
+// GetToken returns the token, reading it from token_file or token_env if set
+func (a TargetAuth) GetToken() (Token, error) {
+	switch {
+	case a.TokenFile != "":
+		return readTokenFile(a.TokenFile)
+	case a.TokenEnv != "":
+		tok, ok := os.LookupEnv(a.TokenEnv)
+		if !ok || strings.TrimSpace(tok) == "" {
+			return "", fmt.Errorf("environment variable %q is not set", a.TokenEnv)
+		}
+		return Token(strings.TrimSpace(tok)), nil
+	default:
+		return a.Token, nil
+	}
+}
+
+// validate checks that either a token or a username and password are set, that
+// at most one token source is set and that it can be read
+func (a TargetAuth) validate() error {
+	if a.HasToken() && (a.Username != "" || a.Password != "") {
+		return fmt.Errorf("token and username/password may not be set together")
+	}
+	if (a.Username != "") != (a.Password != "") {
+		return fmt.Errorf("username and password must be set together")
+	}
+
+	sources := 0
+	for _, s := range []string{string(a.Token), a.TokenFile, a.TokenEnv} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of token, token_file and token_env may be set")
+	}
+	if sources == 1 {
+		if _, err := a.GetToken(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenFileRotation(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "fgt1")
+	if err := os.WriteFile(p, []byte("first-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	a := TargetAuth{TokenFile: p}
+
+	if tok, err := a.GetToken(); err != nil || tok != "first-token" {
+		t.Fatalf("GetToken() = %q, %v, expected \"first-token\"", tok, err)
+	}
+
+	if err := os.WriteFile(p, []byte("second-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the modification time differs on filesystems with coarse timestamps
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(p, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if tok, err := a.GetToken(); err != nil || tok != "second-token" {
+		t.Errorf("GetToken() after rotation = %q, %v, expected \"second-token\"", tok, err)
+	}
+}
+
+func TestTokenEnv(t *testing.T) {
+	t.Setenv("FGT1_TOKEN", "env-token")
+	a := TargetAuth{TokenEnv: "FGT1_TOKEN"}
+	if tok, err := a.GetToken(); err != nil || tok != "env-token" {
+		t.Errorf("GetToken() = %q, %v, expected \"env-token\"", tok, err)
+	}
+}
+
+func TestReInitMissingSecret(t *testing.T) {
+	for _, tc := range []struct {
+		auth string
+		want string
+	}{
+		{"token_file: /does/not/exist", "failed to read token file"},
+		{"token_env: FORTIGATE_EXPORTER_UNSET_TOKEN", "environment variable \"FORTIGATE_EXPORTER_UNSET_TOKEN\" is not set"},
+		{"token: abc\n  token_env: HOME", "only one of token, token_file and token_env may be set"},
+		{"username: admin", "username and password must be set together"},
+		{"password: secret", "username and password must be set together"},
+		{"token: abc\n  username: admin\n  password: secret", "token and username/password may not be set together"},
+	} {
+		writeAuthFile(t, "\"https://my-fortigate\":\n  "+tc.auth+"\n")
+		err := ReInit()
+		if err == nil || !strings.Contains(err.Error(), tc.want) || !strings.Contains(err.Error(), "https://my-fortigate") {
+			t.Errorf("ReInit() with %q = %v, expected error containing %q", tc.auth, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// APIError is returned when the API answers with an unexpected HTTP status code
+// or when the FortiOS response envelope reports an error.
+type APIError struct {
+	// HTTP status, taken from the envelope if the response itself was 200
+	StatusCode int
+	// API path that was requested
+	Path string
+	// VDOM the request was made for, if known
+	VDOM string
+	// FortiOS error code from the envelope, 0 if not reported
+	ErrorCode int
+}
+
+func (e *APIError) Error() string {
+	s := fmt.Sprintf("Response code was %d, expected 200 (path: %q", e.StatusCode, e.Path)
+	if e.VDOM != "" {
+		s += fmt.Sprintf(", vdom: %q", e.VDOM)
+	}
+	if e.ErrorCode != 0 {
+		s += fmt.Sprintf(", error: %d", e.ErrorCode)
+	}
+	return s + ")"
+}
+
+// apiEnvelope holds the fields FortiOS wraps every single-VDOM response in
+type apiEnvelope struct {
+	Status     string `json:"status"`
+	HTTPStatus int    `json:"http_status"`
+	VDOM       string `json:"vdom"`
+	Error      int    `json:"error"`
+}
+
+// checkResponse returns an *APIError if either the status code or the envelope
+// of the response signals an error.
+func checkResponse(statusCode int, path string, query string, body []byte) error {
+	var env apiEnvelope
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		// Not all endpoints return an envelope, ignore anything we cannot parse
+		_ = json.Unmarshal(body, &env)
+	}
+
+	if statusCode == http.StatusOK && env.Status != "error" {
+		return nil
+	}
+
+	e := &APIError{
+		StatusCode: statusCode,
+		Path:       path,
+		VDOM:       env.VDOM,
+		ErrorCode:  env.Error,
+	}
+	if statusCode == http.StatusOK && env.HTTPStatus != 0 {
+		e.StatusCode = env.HTTPStatus
+	}
+	if e.VDOM == "" {
+		if q, err := url.ParseQuery(query); err == nil {
+			e.VDOM = q.Get("vdom")
+		}
+	}
+	return e
+}
+
+// decodeResponse checks the response for errors and unmarshals the body into obj,
+// or streams it to obj if it is a StreamDecoder. The response body is always closed.
+func decodeResponse(resp *http.Response, path string, query string, obj interface{}) error {
+	defer resp.Body.Close()
+
+	if sd, ok := obj.(StreamDecoder); ok && resp.StatusCode == http.StatusOK {
+		return sd.DecodeStream(json.NewDecoder(resp.Body))
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := checkResponse(resp.StatusCode, path, query, b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, obj)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == statusCode
+}
+
+// IsUnauthorized reports whether the API rejected the credentials
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsPermissionDenied reports whether the credentials lack permission for the endpoint
+func IsPermissionDenied(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether the endpoint does not exist, e.g. on older firmware
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
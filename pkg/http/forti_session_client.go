@@ -0,0 +1,203 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// HTTP client for Fortigate API using username/password session authentication
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logoutTimeout bounds the logout request, which may be sent after the
+// scrape context has already expired.
+const logoutTimeout = 5 * time.Second
+
+type fortiSessionClient struct {
+	tgt      url.URL
+	hc       HTTPClient
+	ctx      context.Context
+	username string
+	password string
+
+	mu      sync.Mutex
+	cookies []*http.Cookie
+	csrf    string
+	// generation is bumped on every login so concurrent requests that saw an
+	// expired session only trigger a single re-login
+	generation int
+}
+
+type session struct {
+	cookies    []*http.Cookie
+	csrf       string
+	generation int
+}
+
+func (c *fortiSessionClient) newRequest(method string, path string, query string, body io.Reader, s session) (*http.Request, error) {
+	u := c.tgt
+	u.Path = path
+	u.RawQuery = query
+
+	r, err := http.NewRequestWithContext(c.ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for _, ck := range s.cookies {
+		r.AddCookie(ck)
+	}
+	if s.csrf != "" {
+		r.Header.Add("X-CSRFTOKEN", s.csrf)
+	}
+	return r, nil
+}
+
+// login must be called with c.mu held
+func (c *fortiSessionClient) login() error {
+	form := url.Values{}
+	form.Set("username", c.username)
+	form.Set("secretkey", c.password)
+	form.Set("ajax", "1")
+
+	req, err := c.newRequest("POST", "logincheck", "", strings.NewReader(form.Encode()), session{})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		return &APIError{StatusCode: resp.StatusCode, Path: "logincheck"}
+	}
+	// FortiOS answers "1" on success, "0" on bad credentials, "2" when locked out
+	// and "3" when a second factor is required
+	if !strings.HasPrefix(strings.TrimSpace(string(b)), "1") {
+		return fmt.Errorf("login as %q to %s failed", c.username, c.tgt.String())
+	}
+
+	csrf := ""
+	cookies := resp.Cookies()
+	for _, ck := range cookies {
+		// Newer FortiOS versions suffix the cookie name with the admin port
+		if strings.HasPrefix(ck.Name, "ccsrftoken") {
+			csrf = strings.Trim(ck.Value, `"`)
+		}
+	}
+	if csrf == "" {
+		return fmt.Errorf("login as %q to %s did not return a CSRF token", c.username, c.tgt.String())
+	}
+
+	c.cookies = cookies
+	c.csrf = csrf
+	c.generation++
+	return nil
+}
+
+// session returns the current session, logging in if needed. If stale is
+// given and still is the current session, a new login is made.
+func (c *fortiSessionClient) session(stale *session) (session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cookies == nil || (stale != nil && stale.generation == c.generation) {
+		if err := c.login(); err != nil {
+			return session{}, err
+		}
+	}
+	return session{c.cookies, c.csrf, c.generation}, nil
+}
+
+func (c *fortiSessionClient) Get(path string, query string, obj interface{}) error {
+	s, err := c.session(nil)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.newRequest("GET", path, query, nil, s)
+	if err != nil {
+		return err
+	}
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		// The session has expired, log in again and retry once
+		resp.Body.Close()
+		if s, err = c.session(&s); err != nil {
+			return err
+		}
+		if req, err = c.newRequest("GET", path, query, nil, s); err != nil {
+			return err
+		}
+		if resp, err = c.hc.Do(req); err != nil {
+			return err
+		}
+	}
+
+	return decodeResponse(resp, path, query, obj)
+}
+
+// Close logs out the session, if any
+func (c *fortiSessionClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cookies == nil {
+		return nil
+	}
+	s := session{c.cookies, c.csrf, c.generation}
+	c.cookies = nil
+	c.csrf = ""
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.ctx), logoutTimeout)
+	defer cancel()
+	req, err := c.newRequest("POST", "logout", "", nil, s)
+	if err != nil {
+		return err
+	}
+	resp, err := c.hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return &APIError{StatusCode: resp.StatusCode, Path: "logout"}
+	}
+	return nil
+}
+
+func (c *fortiSessionClient) String() string {
+	return c.tgt.String()
+}
+
+func newFortiSessionClient(ctx context.Context, tgt url.URL, hc HTTPClient, username string, password string) (*fortiSessionClient, error) {
+	return &fortiSessionClient{tgt: tgt, hc: hc, ctx: ctx, username: username, password: password}, nil
+}
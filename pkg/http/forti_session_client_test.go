@@ -0,0 +1,143 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// fakeFortiOS is a minimal stand-in for the FortiOS login/logout flow
+type fakeFortiOS struct {
+	mu       sync.Mutex
+	sessions map[string]bool
+	next     int
+	logins   int
+	logouts  int
+}
+
+func (f *fakeFortiOS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/logincheck":
+		if r.FormValue("username") != "admin" || r.FormValue("secretkey") != "secret" {
+			fmt.Fprint(w, "0")
+			return
+		}
+		f.next++
+		f.logins++
+		id := fmt.Sprintf("session-%d", f.next)
+		f.sessions[id] = true
+		http.SetCookie(w, &http.Cookie{Name: "APSCOOKIE_123", Value: id})
+		http.SetCookie(w, &http.Cookie{Name: "ccsrftoken_443", Value: `"csrf-` + id + `"`})
+		fmt.Fprint(w, "1document.location=\"/ng/prompt?viewOnly&redir=%2Fng%2F\";\n")
+		return
+	}
+
+	ck, err := r.Cookie("APSCOOKIE_123")
+	if err != nil || !f.sessions[ck.Value] || r.Header.Get("X-CSRFTOKEN") != "csrf-"+ck.Value {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/logout":
+		delete(f.sessions, ck.Value)
+		f.logouts++
+	case "/api/v2/monitor/system/status":
+		fmt.Fprint(w, `{ "status": "success", "version": "v6.0.10" }`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeFortiOS) expireSessions() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions = map[string]bool{}
+}
+
+func newSessionClient(t *testing.T, username string, password string) (*fortiSessionClient, *fakeFortiOS) {
+	f := &fakeFortiOS{sessions: map[string]bool{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	u, _ := url.Parse(srv.URL)
+	c, _ := newFortiSessionClient(context.Background(), *u, srv.Client(), username, password)
+	return c, f
+}
+
+func TestSessionGet(t *testing.T) {
+	c, f := newSessionClient(t, "admin", "secret")
+
+	type S struct {
+		Status  string
+		Version string
+	}
+	var v S
+	for i := 0; i < 2; i++ {
+		if err := c.Get("api/v2/monitor/system/status", "", &v); err != nil {
+			t.Fatalf("Get() unexpected error: %v", err)
+		}
+	}
+	if exp := (S{"success", "v6.0.10"}); v != exp {
+		t.Errorf("Get() %v, expected %v", v, exp)
+	}
+	if f.logins != 1 {
+		t.Errorf("expected 1 login, got %d", f.logins)
+	}
+
+	if err := c.Get("api/v2/monitor/does/not/exist", "", &v); !IsNotFound(err) {
+		t.Errorf("Get() expected not found error, got %v", err)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+	if f.logouts != 1 || len(f.sessions) != 0 {
+		t.Errorf("expected session to be logged out, got %d logouts and %d sessions", f.logouts, len(f.sessions))
+	}
+}
+
+func TestSessionRelogin(t *testing.T) {
+	c, f := newSessionClient(t, "admin", "secret")
+
+	if err := c.Get("api/v2/monitor/system/status", "", &struct{}{}); err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	f.expireSessions()
+	if err := c.Get("api/v2/monitor/system/status", "", &struct{}{}); err != nil {
+		t.Fatalf("Get() after session expiry unexpected error: %v", err)
+	}
+	if f.logins != 2 {
+		t.Errorf("expected 2 logins, got %d", f.logins)
+	}
+}
+
+func TestSessionLoginFail(t *testing.T) {
+	c, f := newSessionClient(t, "admin", "wrong")
+
+	if err := c.Get("api/v2/monitor/system/status", "", &struct{}{}); err == nil {
+		t.Errorf("Get() expected non-nil error, got nil error")
+	}
+	if err := c.Close(); err != nil || f.logouts != 0 {
+		t.Errorf("Close() without session: %v, %d logouts", err, f.logouts)
+	}
+}
@@ -32,9 +32,7 @@ package http
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 
@@ -76,15 +74,7 @@ func (c *fortiTokenClient) Get(path string, query string, obj interface{}) error
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("Response code was %d, expected 200 (path: %q)", resp.StatusCode, path)
-	}
-
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(b, obj)
+	return decodeResponse(resp, path, query, obj)
 }
 
 func (c *fortiTokenClient) String() string {
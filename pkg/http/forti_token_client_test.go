@@ -32,6 +32,8 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -43,11 +45,22 @@ import (
 type fakeHTTPClient struct {
 	status int
 	body   string
+	closed bool
+}
+
+type fakeBody struct {
+	io.Reader
+	c *fakeHTTPClient
+}
+
+func (b *fakeBody) Close() error {
+	b.c.closed = true
+	return nil
 }
 
 func (c *fakeHTTPClient) Do(r *http.Request) (*http.Response, error) {
 	return &http.Response{
-		Body:       io.NopCloser(strings.NewReader(c.body)),
+		Body:       &fakeBody{strings.NewReader(c.body), c},
 		StatusCode: c.status,
 	}, nil
 }
@@ -56,7 +69,7 @@ func newClient(sc int, b string) (*fortiTokenClient, error) {
 	return newFortiTokenClient(
 		context.Background(),
 		url.URL{Scheme: "https", Host: "localhost"},
-		&fakeHTTPClient{sc, b},
+		&fakeHTTPClient{status: sc, body: b},
 		"TEST-TOKEN",
 	)
 }
@@ -80,6 +93,90 @@ func TestGetFail(t *testing.T) {
 	}
 	err := c.Get("test", "", &D{})
 	if err == nil {
-		t.Errorf("Get() expected non-nil error, got nil error")
+		t.Fatalf("Get() expected non-nil error, got nil error")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 || apiErr.Path != "test" {
+		t.Errorf("Get() expected APIError with status 404 for path \"test\", got %#v", err)
+	}
+}
+
+func TestGetClosesBody(t *testing.T) {
+	c, _ := newClient(200, `{ "data": "test" }`)
+	if err := c.Get("test", "", &struct{}{}); err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if !c.hc.(*fakeHTTPClient).closed {
+		t.Errorf("Get() did not close the response body")
+	}
+}
+
+func TestGetEnvelopeError(t *testing.T) {
+	c, _ := newClient(403, `{ "http_method": "GET", "status": "error", "http_status": 403, "vdom": "root", "error": -37 }`)
+	err := c.Get("api/v2/monitor/vpn/ipsec", "vdom=*", &struct{}{})
+	exp := &APIError{StatusCode: 403, Path: "api/v2/monitor/vpn/ipsec", VDOM: "root", ErrorCode: -37}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !reflect.DeepEqual(apiErr, exp) {
+		t.Fatalf("Get() error %#v, expected %#v", err, exp)
+	}
+	if !IsPermissionDenied(err) || IsNotFound(err) || IsUnauthorized(err) {
+		t.Errorf("Get() error %v not classified as permission denied", err)
+	}
+}
+
+func TestGetEnvelopeErrorWithStatusOK(t *testing.T) {
+	c, _ := newClient(200, `{ "status": "error", "http_status": 404 }`)
+	err := c.Get("api/v2/monitor/router/bgp/paths", "vdom=FG-traffic", &struct{}{})
+	exp := &APIError{StatusCode: 404, Path: "api/v2/monitor/router/bgp/paths", VDOM: "FG-traffic"}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !reflect.DeepEqual(apiErr, exp) {
+		t.Fatalf("Get() error %#v, expected %#v", err, exp)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("Get() error %v not classified as not found", err)
+	}
+}
+
+func TestGetListResponse(t *testing.T) {
+	c, _ := newClient(200, `[ { "status": "success", "vdom": "root" } ]`)
+	var v []struct{ VDOM string }
+	if err := c.Get("test", "vdom=*", &v); err != nil || len(v) != 1 || v[0].VDOM != "root" {
+		t.Errorf("Get() %v, %v, expected one result for vdom root", v, err)
+	}
+}
+
+// countingDecoder counts the elements of a list response without keeping them
+type countingDecoder struct {
+	n int
+}
+
+func (d *countingDecoder) DecodeStream(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	for dec.More() {
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		d.n++
+	}
+	_, err := dec.Token()
+	return err
+}
+
+func TestGetStream(t *testing.T) {
+	c, _ := newClient(200, `[ { "vdom": "root" }, { "vdom": "dmz" } ]`)
+	d := &countingDecoder{}
+	if err := c.Get("test", "vdom=*", d); err != nil || d.n != 2 {
+		t.Errorf("Get() decoded %d elements, %v, expected 2, nil", d.n, err)
+	}
+	if !c.hc.(*fakeHTTPClient).closed {
+		t.Errorf("Get() did not close the response body")
+	}
+
+	c, _ = newClient(403, `{ "status": "error", "http_status": 403 }`)
+	if err := c.Get("test", "", &countingDecoder{}); !IsPermissionDenied(err) {
+		t.Errorf("Get() expected permission denied error, got %v", err)
 	}
 }
@@ -15,10 +15,9 @@ package http
 
 import (
 	"context"
-	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"time"
@@ -30,18 +29,42 @@ type FortiHTTP interface {
 	Get(path string, query string, obj interface{}) error
 }
 
-func NewFortiClient(ctx context.Context, tgt url.URL, hc *http.Client, aConfig config.FortiExporterConfig) (FortiHTTP, error) {
+// StreamDecoder can be implemented by the obj passed to Get to decode a
+// successful response itself, token by token, instead of having the whole body
+// read and unmarshaled at once. Envelope errors in a response with status 200
+// are then left to the decoder.
+type StreamDecoder interface {
+	DecodeStream(dec *json.Decoder) error
+}
 
-	auth, ok := aConfig.AuthKeys[config.Target(tgt.String())]
-	if !ok {
-		return nil, fmt.Errorf("no API authentication registered for %q", tgt.String())
+func NewFortiClient(ctx context.Context, tgt url.URL, hc *http.Client, auth config.TargetAuth) (FortiHTTP, error) {
+	if hc.Transport == nil {
+		t, err := transports.get(tgt, auth.TLS)
+		if err != nil {
+			return nil, err
+		}
+		thc := *hc
+		thc.Transport = t
+		hc = &thc
 	}
 
-	if auth.Token != "" {
+	if auth.HasToken() {
 		if tgt.Scheme != "https" {
 			return nil, fmt.Errorf("FortiOS only supports token for HTTPS connections")
 		}
-		c, err := newFortiTokenClient(ctx, tgt, hc, auth.Token)
+		tok, err := auth.GetToken()
+		if err != nil {
+			return nil, err
+		}
+		c, err := newFortiTokenClient(ctx, tgt, hc, tok)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	if auth.Username != "" {
+		c, err := newFortiSessionClient(ctx, tgt, hc, auth.Username, auth.Password)
 		if err != nil {
 			return nil, err
 		}
@@ -50,11 +73,14 @@ func NewFortiClient(ctx context.Context, tgt url.URL, hc *http.Client, aConfig c
 	return nil, fmt.Errorf("invalid authentication data for %q", tgt.String())
 }
 
+// Configure sets the TLS defaults for all targets. Transports created for an
+// earlier configuration are dropped, http.DefaultTransport is left untouched.
+// Nothing is changed if the extra CAs or the TLS settings of any target in
+// the auth file are invalid.
 func Configure(config config.FortiExporterConfig) error {
 	roots, err := x509.SystemCertPool()
 	if err != nil {
-		log.Fatalf("Unable to fetch system CA store: %v", err)
-		return err
+		return fmt.Errorf("unable to fetch system CA store: %w", err)
 	}
 	for _, cert := range config.TlsExtraCAs {
 
@@ -62,11 +88,18 @@ func Configure(config config.FortiExporterConfig) error {
 			return fmt.Errorf("failed to append certs from PEM %q, unknown error", cert.Path)
 		}
 	}
-	tc := &tls.Config{RootCAs: roots}
-	if config.TLSInsecure {
-		tc.InsecureSkipVerify = true
+	for t, auth := range config.AuthKeys {
+		if _, err := buildTLSConfig(roots, config.TLSInsecure, auth.TLS); err != nil {
+			return fmt.Errorf("TLS configuration for %q: %w", t, err)
+		}
+	}
+
+	// Transports of targets that are no longer scraped are dropped along the
+	// lines of the dynamic target limits, with room for the auth file targets
+	max := 0
+	if config.MaxDynamic > 0 {
+		max = config.MaxDynamic + len(config.AuthKeys)
 	}
-	http.DefaultTransport.(*http.Transport).TLSHandshakeTimeout = time.Duration(config.TLSTimeout) * time.Second
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = tc
+	transports.reset(roots, config.TLSInsecure, time.Duration(config.TLSTimeout)*time.Second, time.Duration(config.DynamicTTL)*time.Second, max)
 	return nil
 }
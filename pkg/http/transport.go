@@ -0,0 +1,163 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+type transportKey struct {
+	host string
+	tls  config.TLSConfig
+}
+
+// transportCache keeps one transport per target and TLS settings so that
+// connections are reused between scrapes. Like the dynamic targets, entries
+// expire when they have not been used for the TTL and the least recently used
+// one is evicted when the cache is full.
+type transportCache struct {
+	mu         sync.Mutex
+	roots      *x509.CertPool
+	insecure   bool
+	timeout    time.Duration
+	ttl        time.Duration
+	max        int
+	now        func() time.Time
+	transports map[transportKey]*cachedTransport
+}
+
+type cachedTransport struct {
+	t        *http.Transport
+	lastUsed time.Time
+}
+
+var transports = &transportCache{
+	timeout:    10 * time.Second,
+	now:        time.Now,
+	transports: map[transportKey]*cachedTransport{},
+}
+
+// reset drops all transports and sets the defaults for new ones, a ttl or max
+// of 0 means no limit
+func (c *transportCache) reset(roots *x509.CertPool, insecure bool, timeout time.Duration, ttl time.Duration, max int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.transports {
+		e.t.CloseIdleConnections()
+	}
+	c.roots = roots
+	c.insecure = insecure
+	c.timeout = timeout
+	c.ttl = ttl
+	c.max = max
+	c.transports = map[transportKey]*cachedTransport{}
+}
+
+func (c *transportCache) get(tgt url.URL, tc config.TLSConfig) (*http.Transport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := transportKey{tgt.Host, tc}
+	if e, ok := c.transports[key]; ok && !c.expired(e) {
+		e.lastUsed = c.now()
+		return e.t, nil
+	}
+
+	tlsConfig, err := c.tlsConfig(tc)
+	if err != nil {
+		return nil, fmt.Errorf("TLS configuration for %q: %w", tgt.String(), err)
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSHandshakeTimeout = c.timeout
+	t.TLSClientConfig = tlsConfig
+	c.transports[key] = &cachedTransport{t: t, lastUsed: c.now()}
+	c.evict()
+	return t, nil
+}
+
+func (c *transportCache) expired(e *cachedTransport) bool {
+	return c.ttl > 0 && c.now().Sub(e.lastUsed) > c.ttl
+}
+
+// evict must be called with c.mu held
+func (c *transportCache) evict() {
+	for k, e := range c.transports {
+		if c.expired(e) {
+			e.t.CloseIdleConnections()
+			delete(c.transports, k)
+		}
+	}
+	for c.max > 0 && len(c.transports) > c.max {
+		var oldest transportKey
+		var oldestUsed time.Time
+		for k, e := range c.transports {
+			if oldestUsed.IsZero() || e.lastUsed.Before(oldestUsed) {
+				oldest, oldestUsed = k, e.lastUsed
+			}
+		}
+		c.transports[oldest].t.CloseIdleConnections()
+		delete(c.transports, oldest)
+	}
+}
+
+// tlsConfig must be called with c.mu held
+func (c *transportCache) tlsConfig(tc config.TLSConfig) (*tls.Config, error) {
+	return buildTLSConfig(c.roots, c.insecure, tc)
+}
+
+// buildTLSConfig applies the per target TLS settings on top of the defaults
+func buildTLSConfig(roots *x509.CertPool, insecure bool, tc config.TLSConfig) (*tls.Config, error) {
+	if tc.CAFile != "" {
+		if roots != nil {
+			roots = roots.Clone()
+		} else {
+			var err error
+			if roots, err = x509.SystemCertPool(); err != nil {
+				return nil, fmt.Errorf("unable to fetch system CA store: %w", err)
+			}
+		}
+		pem, err := os.ReadFile(tc.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		if ok := roots.AppendCertsFromPEM(pem); !ok {
+			return nil, fmt.Errorf("failed to append certs from PEM %q, unknown error", tc.CAFile)
+		}
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:            roots,
+		ServerName:         tc.ServerName,
+		InsecureSkipVerify: insecure || tc.InsecureSkipVerify,
+	}
+
+	if tc.CertFile != "" || tc.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tc.CertFile, tc.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
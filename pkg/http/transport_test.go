@@ -0,0 +1,157 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func newTLSTarget(t *testing.T) (url.URL, string) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{ "status": "success" }`)
+	}))
+	t.Cleanup(srv.Close)
+
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(ca, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(srv.URL)
+	return *u, ca
+}
+
+func getStatus(tgt url.URL, auth config.TargetAuth) error {
+	c, err := NewFortiClient(context.Background(), tgt, &http.Client{}, auth)
+	if err != nil {
+		return err
+	}
+	return c.Get("api/v2/monitor/system/status", "", &struct{}{})
+}
+
+func TestPerTargetTLS(t *testing.T) {
+	if err := Configure(config.FortiExporterConfig{TLSTimeout: 10}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+	defaultTLS := http.DefaultTransport.(*http.Transport).TLSClientConfig
+
+	tgt, ca := newTLSTarget(t)
+
+	if err := getStatus(tgt, config.TargetAuth{Token: "TEST-TOKEN"}); err == nil {
+		t.Errorf("Get() of self-signed target without TLS settings succeeded")
+	}
+	if err := getStatus(tgt, config.TargetAuth{Token: "TEST-TOKEN", TLS: config.TLSConfig{InsecureSkipVerify: true}}); err != nil {
+		t.Errorf("Get() with insecure_skip_verify failed: %v", err)
+	}
+	if err := getStatus(tgt, config.TargetAuth{Token: "TEST-TOKEN", TLS: config.TLSConfig{CAFile: ca}}); err != nil {
+		t.Errorf("Get() with ca_file failed: %v", err)
+	}
+	if err := getStatus(tgt, config.TargetAuth{Token: "TEST-TOKEN", TLS: config.TLSConfig{CAFile: ca, ServerName: "wrong.example"}}); err == nil {
+		t.Errorf("Get() with mismatching server_name succeeded")
+	}
+	if err := getStatus(tgt, config.TargetAuth{Token: "TEST-TOKEN", TLS: config.TLSConfig{CertFile: ca}}); err == nil {
+		t.Errorf("Get() with broken client certificate succeeded")
+	}
+
+	if http.DefaultTransport.(*http.Transport).TLSClientConfig != defaultTLS {
+		t.Errorf("http.DefaultTransport was modified")
+	}
+}
+
+func TestTransportCache(t *testing.T) {
+	if err := Configure(config.FortiExporterConfig{TLSTimeout: 10}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+	tgt := url.URL{Scheme: "https", Host: "fortigate:8443"}
+
+	t1, _ := transports.get(tgt, config.TLSConfig{})
+	t2, _ := transports.get(tgt, config.TLSConfig{})
+	t3, _ := transports.get(tgt, config.TLSConfig{InsecureSkipVerify: true})
+	if t1 != t2 {
+		t.Errorf("transport for the same target was not reused")
+	}
+	if t1 == t3 {
+		t.Errorf("transport was reused for different TLS settings")
+	}
+
+	if err := Configure(config.FortiExporterConfig{TLSTimeout: 10, TLSInsecure: true}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+	t4, _ := transports.get(tgt, config.TLSConfig{})
+	if t4 == t1 || !t4.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("transport was not recreated after Configure()")
+	}
+}
+
+func TestConfigureInvalidTLS(t *testing.T) {
+	if err := Configure(config.FortiExporterConfig{TLSTimeout: 10}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+	tgt := url.URL{Scheme: "https", Host: "fortigate:8443"}
+	t1, _ := transports.get(tgt, config.TLSConfig{})
+
+	broken := config.FortiExporterConfig{
+		TLSTimeout:  10,
+		TLSInsecure: true,
+		AuthKeys: config.AuthKeys{
+			"https://fortigate:8443": {Token: "TEST-TOKEN", TLS: config.TLSConfig{CAFile: "/does/not/exist.pem"}},
+		},
+	}
+	if err := Configure(broken); err == nil {
+		t.Fatalf("Configure() with a missing ca_file succeeded")
+	}
+	if t2, _ := transports.get(tgt, config.TLSConfig{}); t2 != t1 || t2.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("failed Configure() changed the transports")
+	}
+}
+
+func TestTransportCacheEviction(t *testing.T) {
+	defer func() { transports.now = time.Now }()
+	now := time.Unix(1600000000, 0)
+	transports.now = func() time.Time { return now }
+
+	if err := Configure(config.FortiExporterConfig{TLSTimeout: 10, DynamicTTL: 60, MaxDynamic: 1}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+	a := url.URL{Scheme: "https", Host: "fortigate-a"}
+	b := url.URL{Scheme: "https", Host: "fortigate-b"}
+
+	ta, _ := transports.get(a, config.TLSConfig{})
+	now = now.Add(time.Second)
+	transports.get(b, config.TLSConfig{})
+	if len(transports.transports) != 1 {
+		t.Errorf("expected 1 cached transport, got %d", len(transports.transports))
+	}
+	if ta2, _ := transports.get(a, config.TLSConfig{}); ta2 == ta {
+		t.Errorf("least recently used transport was not evicted")
+	}
+
+	now = now.Add(2 * time.Minute)
+	tb, _ := transports.get(b, config.TLSConfig{})
+	now = now.Add(2 * time.Minute)
+	if tb2, _ := transports.get(b, config.TLSConfig{}); tb2 == tb {
+		t.Errorf("expired transport was reused")
+	}
+}
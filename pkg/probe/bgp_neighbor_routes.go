@@ -14,162 +14,245 @@
 package probe
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 
-	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// bgpPathsPageSize is the number of BGP paths requested at once
+var bgpPathsPageSize = 1000
+
 type BGPPath struct {
 	LearnedFrom string `json:"learned_from"`
 	IsBest      bool   `json:"is_best"`
 }
 
-type BGPPaths struct {
-	Results []BGPPath `json:"results"`
-	VDOM    string    `json:"vdom"`
-	Version string    `json:"version"`
-}
-
 type PathCount struct {
 	Source string
 	VDOM   string
 }
 
-func probeBGPNeighborPathsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
-	savedConfig := config.GetConfig()
-	MaxBGPPaths := savedConfig.MaxBGPPaths
-
-	if MaxBGPPaths == 0 {
-		return nil, true
-	}
+// bgpPathCounter counts the BGP paths per neighbor while the response is
+// decoded as a stream, so the paths themselves are never kept in memory
+type bgpPathCounter struct {
+	path  string
+	paths map[PathCount]int
+	best  map[PathCount]int
+	// limit is how many paths of the current page are counted per VDOM,
+	// received is how many were returned per VDOM
+	limit    int
+	received map[string]int
+}
 
-	if meta.VersionMajor < 7 {
-		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
-		return nil, true
+func (b *bgpPathCounter) DecodeStream(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
 	}
-	var (
-		BGPNeighborPaths = prometheus.NewDesc(
-			"fortigate_bgp_neighbor_ipv4_paths",
-			"Count of paths received from an BGP neighbor",
-			[]string{"vdom", "neighbor_ip"}, nil,
-		)
-		BGPNeighborBestPaths = prometheus.NewDesc(
-			"fortigate_bgp_neighbor_ipv4_best_paths",
-			"Count of best paths for an BGP neighbor",
-			[]string{"vdom", "neighbor_ip"}, nil,
-		)
-	)
-
-	var rs []BGPPaths
-
-	if err := c.Get("api/v2/monitor/router/bgp/paths", fmt.Sprintf("vdom=*&count=%d", MaxBGPPaths), &rs); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+	switch tok {
+	case json.Delim('['):
+		for dec.More() {
+			if tok, err = dec.Token(); err != nil {
+				return err
+			}
+			if tok != json.Delim('{') {
+				return fmt.Errorf("unexpected %v in BGP paths list", tok)
+			}
+			err := b.decodeEnvelope(dec)
+			var apiErr *http.APIError
+			if errors.As(err, &apiErr) {
+				// Keep counting the paths of the other VDOMs
+				log.Printf("Warning: skipping BGP paths of VDOM %q: %v", apiErr.VDOM, err)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+		return err
+	case json.Delim('{'):
+		return b.decodeEnvelope(dec)
+	default:
+		return fmt.Errorf("unexpected %v in BGP paths response", tok)
 	}
+}
 
-	m := []prometheus.Metric{}
-
-	srMap := make(map[PathCount]int)
-	sr2Map := make(map[PathCount]int)
-	for _, r := range rs {
+// decodeEnvelope decodes the fields of one VDOM's response, the opening brace
+// has already been read
+func (b *bgpPathCounter) decodeEnvelope(dec *json.Decoder) error {
+	var vdom, status string
+	var httpStatus, errorCode int
+	n := 0
+	paths := map[string]int{}
+	best := map[string]int{}
 
-		if len(r.Results) > MaxBGPPaths {
-			log.Printf("Error: Received more BGP Paths than maximum (%d > %d) allowed, ignoring metric ...", len(r.Results), MaxBGPPaths)
-			return nil, false
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
 		}
-		for _, route := range r.Results {
-			sr := PathCount{
-				Source: route.LearnedFrom,
-				VDOM:   r.VDOM,
+		switch tok {
+		case "vdom":
+			err = dec.Decode(&vdom)
+		case "status":
+			err = dec.Decode(&status)
+		case "http_status":
+			err = dec.Decode(&httpStatus)
+		case "error":
+			err = dec.Decode(&errorCode)
+		case "results":
+			if tok, err = dec.Token(); err != nil {
+				return err
+			}
+			if tok == nil {
+				// No paths at all
+				break
+			}
+			if tok != json.Delim('[') {
+				return fmt.Errorf("unexpected %v in BGP paths results", tok)
 			}
-			srMap[sr] += 1
-			if route.IsBest {
-				sr2 := PathCount{
-					Source: route.LearnedFrom,
-					VDOM:   r.VDOM,
+			for dec.More() {
+				var route BGPPath
+				if err := dec.Decode(&route); err != nil {
+					return err
+				}
+				n++
+				if n > b.limit {
+					continue
+				}
+				paths[route.LearnedFrom]++
+				if route.IsBest {
+					best[route.LearnedFrom]++
 				}
-				sr2Map[sr2] += 1
 			}
+			_, err = dec.Token()
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return err
 		}
 	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
 
-	for neighbor, count := range srMap {
-		m = append(m, prometheus.MustNewConstMetric(BGPNeighborPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
+	if status == "error" {
+		return &http.APIError{StatusCode: httpStatus, Path: b.path, VDOM: vdom, ErrorCode: errorCode}
 	}
-	for neighbor, count := range sr2Map {
-		m = append(m, prometheus.MustNewConstMetric(BGPNeighborBestPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
+	b.received[vdom] += n
+	for source, count := range paths {
+		b.paths[PathCount{Source: source, VDOM: vdom}] += count
+	}
+	for source, count := range best {
+		b.best[PathCount{Source: source, VDOM: vdom}] += count
 	}
+	return nil
+}
+
+func probeBGPNeighborPathsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	return probeBGPNeighborPaths(c, meta, "ipv4", "api/v2/monitor/router/bgp/neighbors", "api/v2/monitor/router/bgp/paths")
+}
 
-	return m, true
+func probeBGPNeighborPathsIPv6(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	return probeBGPNeighborPaths(c, meta, "ipv6", "api/v2/monitor/router/bgp/neighbors6", "api/v2/monitor/router/bgp/paths6")
 }
 
-func probeBGPNeighborPathsIPv6(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
-	savedConfig := config.GetConfig()
-	MaxBGPPaths := savedConfig.MaxBGPPaths
+func probeBGPNeighborPaths(c http.FortiHTTP, meta *TargetMetadata, family string, neighborsPath string, pathsPath string) ([]prometheus.Metric, error) {
+	MaxBGPPaths := maxBGPPaths(meta)
 
 	if MaxBGPPaths == 0 {
-		return nil, true
+		return nil, nil
 	}
 
 	if meta.VersionMajor < 7 {
 		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
-		return nil, true
+		return nil, nil
 	}
 	var (
 		BGPNeighborPaths = prometheus.NewDesc(
-			"fortigate_bgp_neighbor_ipv6_paths",
+			"fortigate_bgp_neighbor_"+family+"_paths",
 			"Count of paths received from an BGP neighbor",
 			[]string{"vdom", "neighbor_ip"}, nil,
 		)
 		BGPNeighborBestPaths = prometheus.NewDesc(
-			"fortigate_bgp_neighbor_ipv6_best_paths",
+			"fortigate_bgp_neighbor_"+family+"_best_paths",
 			"Count of best paths for an BGP neighbor",
 			[]string{"vdom", "neighbor_ip"}, nil,
 		)
+		BGPPathsTruncated = prometheus.NewDesc(
+			"fortigate_bgp_neighbor_"+family+"_paths_truncated",
+			"Whether the VDOM has more BGP paths than the configured maximum, which were not counted",
+			[]string{"vdom"}, nil,
+		)
 	)
 
-	var rs []BGPPaths
+	if bgpSkipPaths(meta) {
+		var ns []BGPNeighborResponse
 
-	if err := c.Get("api/v2/monitor/router/bgp/paths6", fmt.Sprintf("vdom=*&count=%d", MaxBGPPaths), &rs); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		if err := c.Get(neighborsPath, "vdom=*", &ns); err != nil {
+			log.Printf("Warning: failed to fetch BGP neighbors, counting the paths instead: %v", err)
+		} else if bgpNeighborsHavePrefixCounts(ns) {
+			// The prefix counts are exported by probeBGPNeighbors, no need to
+			// download all paths
+			return nil, nil
+		}
 	}
 
-	m := []prometheus.Metric{}
-
-	srMap := make(map[PathCount]int)
-	sr2Map := make(map[PathCount]int)
-	for _, r := range rs {
+	counter := &bgpPathCounter{
+		path:  pathsPath,
+		paths: map[PathCount]int{},
+		best:  map[PathCount]int{},
+	}
+	truncated := map[string]bool{}
+	for start := 0; start < MaxBGPPaths; start += counter.limit {
+		count := MaxBGPPaths - start
+		counter.limit = count
+		if count > bgpPathsPageSize {
+			count = bgpPathsPageSize
+			counter.limit = count
+		} else {
+			// Ask for one path more on the last page to know if any were left out
+			count++
+		}
+		counter.received = map[string]int{}
 
-		if len(r.Results) > MaxBGPPaths {
-			log.Printf("Error: Received more BGP Paths than maximum (%d > %d) allowed, ignoring metric ...", len(r.Results), MaxBGPPaths)
-			return nil, false
+		if err := c.Get(pathsPath, fmt.Sprintf("vdom=*&start=%d&count=%d", start, count), counter); err != nil {
+			return nil, err
 		}
-		for _, route := range r.Results {
-			sr := PathCount{
-				Source: route.LearnedFrom,
-				VDOM:   r.VDOM,
-			}
-			srMap[sr] += 1
-			if route.IsBest {
-				sr2 := PathCount{
-					Source: route.LearnedFrom,
-					VDOM:   r.VDOM,
-				}
-				sr2Map[sr2] += 1
+
+		more := false
+		for vdom, n := range counter.received {
+			truncated[vdom] = truncated[vdom] || n > counter.limit
+			if n >= count {
+				more = true
 			}
 		}
+		if !more {
+			break
+		}
 	}
 
-	for neighbor, count := range srMap {
+	m := []prometheus.Metric{}
+	for neighbor, count := range counter.paths {
 		m = append(m, prometheus.MustNewConstMetric(BGPNeighborPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
 	}
-	for neighbor, count := range sr2Map {
+	for neighbor, count := range counter.best {
 		m = append(m, prometheus.MustNewConstMetric(BGPNeighborBestPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
 	}
+	for vdom, t := range truncated {
+		v := 0.0
+		if t {
+			v = 1.0
+		}
+		m = append(m, prometheus.MustNewConstMetric(BGPPathsTruncated, prometheus.GaugeValue, v, vdom))
+	}
 
-	return m, true
+	return m, nil
 }
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
@@ -25,6 +26,7 @@ import (
 func TestBGPNeighborPathsIPv4(t *testing.T) {
 	config.MustReInit()
 	c := newFakeClient()
+	c.prepare("api/v2/monitor/router/bgp/neighbors", "testdata/router-bgp-neighbors-v4.jsonnet")
 	c.prepare("api/v2/monitor/router/bgp/paths", "testdata/router-bgp-paths-v4.jsonnet")
 	r := prometheus.NewPedanticRegistry()
 	if !testProbe(probeBGPNeighborPathsIPv4, c, r) {
@@ -40,6 +42,9 @@ func TestBGPNeighborPathsIPv4(t *testing.T) {
     # TYPE fortigate_bgp_neighbor_ipv4_paths gauge
     fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
     fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.2",vdom="root"} 2
+    # HELP fortigate_bgp_neighbor_ipv4_paths_truncated Whether the VDOM has more BGP paths than the configured maximum, which were not counted
+    # TYPE fortigate_bgp_neighbor_ipv4_paths_truncated gauge
+    fortigate_bgp_neighbor_ipv4_paths_truncated{vdom="root"} 0
 	`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
@@ -54,6 +59,7 @@ func TestBGPNeighborPathsIPv6(t *testing.T) {
 	}
 
 	c := newFakeClient()
+	c.prepare("api/v2/monitor/router/bgp/neighbors6", "testdata/router-bgp-neighbors-v6.jsonnet")
 	c.prepare("api/v2/monitor/router/bgp/paths6", "testdata/router-bgp-paths-v6.jsonnet")
 	r := prometheus.NewPedanticRegistry()
 	if !testProbe(probeBGPNeighborPathsIPv6, c, r) {
@@ -69,6 +75,159 @@ func TestBGPNeighborPathsIPv6(t *testing.T) {
     # TYPE fortigate_bgp_neighbor_ipv6_paths gauge
     fortigate_bgp_neighbor_ipv6_paths{neighbor_ip="::",vdom="root"} 1
     fortigate_bgp_neighbor_ipv6_paths{neighbor_ip="fd00::1",vdom="root"} 3
+    # HELP fortigate_bgp_neighbor_ipv6_paths_truncated Whether the VDOM has more BGP paths than the configured maximum, which were not counted
+    # TYPE fortigate_bgp_neighbor_ipv6_paths_truncated gauge
+    fortigate_bgp_neighbor_ipv6_paths_truncated{vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestBGPNeighborPathsModuleLimit(t *testing.T) {
+	config.MustReInit()
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/router/bgp/neighbors", "testdata/router-bgp-neighbors-v4.jsonnet")
+	c.prepare("api/v2/monitor/router/bgp/paths", "testdata/router-bgp-paths-v4.jsonnet")
+	meta := &TargetMetadata{
+		VersionMajor: 7,
+		VersionMinor: 0,
+		Module:       &config.Module{MaxBGPPaths: 1},
+	}
+	r := prometheus.NewPedanticRegistry()
+	if !testProbeWithMetadata(probeBGPNeighborPathsIPv4, c, meta, r) {
+		t.Errorf("probeBGPNeighborPathsIPv4() returned non-success")
+	}
+
+	em := `
+    # HELP fortigate_bgp_neighbor_ipv4_best_paths Count of best paths for an BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_best_paths gauge
+    fortigate_bgp_neighbor_ipv4_best_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
+    # HELP fortigate_bgp_neighbor_ipv4_paths Count of paths received from an BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_paths gauge
+    fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
+    # HELP fortigate_bgp_neighbor_ipv4_paths_truncated Whether the VDOM has more BGP paths than the configured maximum, which were not counted
+    # TYPE fortigate_bgp_neighbor_ipv4_paths_truncated gauge
+    fortigate_bgp_neighbor_ipv4_paths_truncated{vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestBGPNeighborPathsPaged(t *testing.T) {
+	config.MustReInit()
+	defer func(n int) { bgpPathsPageSize = n }(bgpPathsPageSize)
+	bgpPathsPageSize = 2
+
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/router/bgp/neighbors", "testdata/router-bgp-neighbors-v4.jsonnet")
+	c.prepare("api/v2/monitor/router/bgp/paths?start=0&count=2", "testdata/router-bgp-paths-v4-page1.jsonnet")
+	c.prepare("api/v2/monitor/router/bgp/paths?start=2&count=2", "testdata/router-bgp-paths-v4-page2.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeBGPNeighborPathsIPv4, c, r) {
+		t.Errorf("probeBGPNeighborPathsIPv4() returned non-success")
+	}
+
+	em := `
+    # HELP fortigate_bgp_neighbor_ipv4_best_paths Count of best paths for an BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_best_paths gauge
+    fortigate_bgp_neighbor_ipv4_best_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
+    fortigate_bgp_neighbor_ipv4_best_paths{neighbor_ip="10.0.0.2",vdom="root"} 1
+    # HELP fortigate_bgp_neighbor_ipv4_paths Count of paths received from an BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_paths gauge
+    fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
+    fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.2",vdom="root"} 2
+    # HELP fortigate_bgp_neighbor_ipv4_paths_truncated Whether the VDOM has more BGP paths than the configured maximum, which were not counted
+    # TYPE fortigate_bgp_neighbor_ipv4_paths_truncated gauge
+    fortigate_bgp_neighbor_ipv4_paths_truncated{vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestBGPNeighborPathsFromSummary(t *testing.T) {
+	config.MustReInit()
+	c := newFakeClient()
+	// The paths are not prepared, the fake client fails if they are requested
+	c.prepare("api/v2/monitor/router/bgp/neighbors", "testdata/router-bgp-neighbors-v4-details.jsonnet")
+	meta := &TargetMetadata{
+		VersionMajor: 7,
+		VersionMinor: 0,
+		Module:       &config.Module{BGPSkipPaths: true},
+	}
+	r := prometheus.NewPedanticRegistry()
+	if !testProbeWithMetadata(probeBGPNeighborPathsIPv4, c, meta, r) {
+		t.Errorf("probeBGPNeighborPathsIPv4() returned non-success")
+	}
+
+	if n, err := testutil.GatherAndCount(r); err != nil || n != 0 {
+		t.Errorf("expected no metrics, got %d (err %v)", n, err)
+	}
+}
+
+func TestBGPNeighborPathsNotSkippedByDefault(t *testing.T) {
+	config.MustReInit()
+	c := newFakeClient()
+	// The neighbors are not prepared, the fake client fails if they are requested
+	c.prepare("api/v2/monitor/router/bgp/paths", "testdata/router-bgp-paths-v4.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeBGPNeighborPathsIPv4, c, r) {
+		t.Errorf("probeBGPNeighborPathsIPv4() returned non-success")
+	}
+
+	if n, err := testutil.GatherAndCount(r, "fortigate_bgp_neighbor_ipv4_paths", "fortigate_bgp_neighbor_ipv4_best_paths"); err != nil || n != 4 {
+		t.Errorf("expected 4 path metrics, got %d (err %v)", n, err)
+	}
+}
+
+func TestBGPNeighborPathsWithoutNeighbors(t *testing.T) {
+	config.MustReInit()
+	c := newFakeClient()
+	c.prepareError("api/v2/monitor/router/bgp/neighbors", &http.APIError{StatusCode: 403})
+	c.prepare("api/v2/monitor/router/bgp/paths", "testdata/router-bgp-paths-v4.jsonnet")
+	meta := &TargetMetadata{
+		VersionMajor: 7,
+		VersionMinor: 0,
+		Module:       &config.Module{BGPSkipPaths: true},
+	}
+	r := prometheus.NewPedanticRegistry()
+	if !testProbeWithMetadata(probeBGPNeighborPathsIPv4, c, meta, r) {
+		t.Errorf("probeBGPNeighborPathsIPv4() returned non-success")
+	}
+
+	if n, err := testutil.GatherAndCount(r, "fortigate_bgp_neighbor_ipv4_paths", "fortigate_bgp_neighbor_ipv4_best_paths"); err != nil || n != 4 {
+		t.Errorf("expected 4 path metrics, got %d (err %v)", n, err)
+	}
+}
+
+func TestBGPNeighborPathsVDOMErrors(t *testing.T) {
+	config.MustReInit()
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/router/bgp/neighbors", "testdata/router-bgp-neighbors-v4.jsonnet")
+	c.prepare("api/v2/monitor/router/bgp/paths", "testdata/router-bgp-paths-v4-vdoms.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeBGPNeighborPathsIPv4, c, r) {
+		t.Errorf("probeBGPNeighborPathsIPv4() returned non-success")
+	}
+
+	em := `
+    # HELP fortigate_bgp_neighbor_ipv4_best_paths Count of best paths for an BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_best_paths gauge
+    fortigate_bgp_neighbor_ipv4_best_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
+    fortigate_bgp_neighbor_ipv4_best_paths{neighbor_ip="10.0.0.2",vdom="root"} 1
+    # HELP fortigate_bgp_neighbor_ipv4_paths Count of paths received from an BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_paths gauge
+    fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
+    fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.2",vdom="root"} 2
+    # HELP fortigate_bgp_neighbor_ipv4_paths_truncated Whether the VDOM has more BGP paths than the configured maximum, which were not counted
+    # TYPE fortigate_bgp_neighbor_ipv4_paths_truncated gauge
+    fortigate_bgp_neighbor_ipv4_paths_truncated{vdom="nobgp"} 0
+    fortigate_bgp_neighbor_ipv4_paths_truncated{vdom="root"} 0
 	`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
@@ -14,7 +14,6 @@
 package probe
 
 import (
-	"log"
 	"strconv"
 
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
@@ -27,6 +26,13 @@ type BGPNeighbor struct {
 	RemoteAS    int    `json:"remote_as"`
 	AdminStatus bool   `json:"admin_status"`
 	State       string `json:"state"`
+	// Session details and prefix counts, only reported by newer firmware
+	Uptime                 *float64 `json:"uptime"`
+	EstablishedTransitions *float64 `json:"established_transitions"`
+	MessagesReceived       *float64 `json:"messages_received"`
+	MessagesSent           *float64 `json:"messages_sent"`
+	AcceptedPrefixes       *float64 `json:"accepted_prefixes"`
+	AdvertisedPrefixes     *float64 `json:"advertised_prefixes"`
 }
 
 type BGPNeighborResponse struct {
@@ -35,10 +41,10 @@ type BGPNeighborResponse struct {
 	Version string        `json:"version"`
 }
 
-func probeBGPNeighborsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeBGPNeighborsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	if meta.VersionMajor < 7 {
 		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
-		return nil, true
+		return nil, nil
 	}
 	var (
 		mBGPNeighbor = prometheus.NewDesc(
@@ -48,11 +54,12 @@ func probeBGPNeighborsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 		)
 	)
 
+	details := newBGPNeighborDetails("ipv4")
+
 	var rs []BGPNeighborResponse
 
 	if err := c.Get("api/v2/monitor/router/bgp/neighbors", "vdom=*", &rs); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -60,16 +67,17 @@ func probeBGPNeighborsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 	for _, r := range rs {
 		for _, peer := range r.Results {
 			m = append(m, prometheus.MustNewConstMetric(mBGPNeighbor, prometheus.GaugeValue, bgpStateToNumber(peer.State), r.VDOM, strconv.Itoa(peer.RemoteAS), peer.State, strconv.FormatBool(peer.AdminStatus), peer.LocalIP, peer.NeighborIP))
+			m = append(m, details.metrics(r.VDOM, peer)...)
 		}
 	}
 
-	return m, true
+	return m, nil
 }
 
-func probeBGPNeighborsIPv6(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeBGPNeighborsIPv6(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	if meta.VersionMajor < 7 {
 		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
-		return nil, true
+		return nil, nil
 	}
 
 	var (
@@ -80,11 +88,12 @@ func probeBGPNeighborsIPv6(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 		)
 	)
 
+	details := newBGPNeighborDetails("ipv6")
+
 	var rs []BGPNeighborResponse
 
 	if err := c.Get("api/v2/monitor/router/bgp/neighbors6", "vdom=*", &rs); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -92,10 +101,94 @@ func probeBGPNeighborsIPv6(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 	for _, r := range rs {
 		for _, peer := range r.Results {
 			m = append(m, prometheus.MustNewConstMetric(mBGPNeighbor, prometheus.GaugeValue, bgpStateToNumber(peer.State), r.VDOM, strconv.Itoa(peer.RemoteAS), peer.State, strconv.FormatBool(peer.AdminStatus), peer.LocalIP, peer.NeighborIP))
+			m = append(m, details.metrics(r.VDOM, peer)...)
+		}
+	}
+
+	return m, nil
+}
+
+// bgpNeighborDetails holds the descriptors of the optional per neighbor metrics
+type bgpNeighborDetails struct {
+	uptime                 *prometheus.Desc
+	establishedTransitions *prometheus.Desc
+	messagesReceived       *prometheus.Desc
+	messagesSent           *prometheus.Desc
+	acceptedPrefixes       *prometheus.Desc
+	advertisedPrefixes     *prometheus.Desc
+}
+
+func newBGPNeighborDetails(family string) bgpNeighborDetails {
+	labels := []string{"vdom", "neighbor_ip"}
+	return bgpNeighborDetails{
+		uptime: prometheus.NewDesc(
+			"fortigate_bgp_neighbor_"+family+"_uptime_seconds",
+			"Seconds the BGP session has been in its current state",
+			labels, nil,
+		),
+		establishedTransitions: prometheus.NewDesc(
+			"fortigate_bgp_neighbor_"+family+"_established_transitions_total",
+			"Number of times the BGP session has reached the established state",
+			labels, nil,
+		),
+		messagesReceived: prometheus.NewDesc(
+			"fortigate_bgp_neighbor_"+family+"_messages_received_total",
+			"Number of BGP messages received from the neighbor",
+			labels, nil,
+		),
+		messagesSent: prometheus.NewDesc(
+			"fortigate_bgp_neighbor_"+family+"_messages_sent_total",
+			"Number of BGP messages sent to the neighbor",
+			labels, nil,
+		),
+		acceptedPrefixes: prometheus.NewDesc(
+			"fortigate_bgp_neighbor_"+family+"_accepted_prefixes",
+			"Number of prefixes accepted from the BGP neighbor",
+			labels, nil,
+		),
+		advertisedPrefixes: prometheus.NewDesc(
+			"fortigate_bgp_neighbor_"+family+"_advertised_prefixes",
+			"Number of prefixes advertised to the BGP neighbor",
+			labels, nil,
+		),
+	}
+}
+
+// metrics returns the metrics for the details the firmware reported for the neighbor
+func (d bgpNeighborDetails) metrics(vdom string, peer BGPNeighbor) []prometheus.Metric {
+	m := []prometheus.Metric{}
+	for _, v := range []struct {
+		desc  *prometheus.Desc
+		vtype prometheus.ValueType
+		value *float64
+	}{
+		{d.uptime, prometheus.GaugeValue, peer.Uptime},
+		{d.establishedTransitions, prometheus.CounterValue, peer.EstablishedTransitions},
+		{d.messagesReceived, prometheus.CounterValue, peer.MessagesReceived},
+		{d.messagesSent, prometheus.CounterValue, peer.MessagesSent},
+		{d.acceptedPrefixes, prometheus.GaugeValue, peer.AcceptedPrefixes},
+		{d.advertisedPrefixes, prometheus.GaugeValue, peer.AdvertisedPrefixes},
+	} {
+		if v.value != nil {
+			m = append(m, prometheus.MustNewConstMetric(v.desc, v.vtype, *v.value, vdom, peer.NeighborIP))
 		}
 	}
+	return m
+}
 
-	return m, true
+// bgpNeighborsHavePrefixCounts returns true if there are neighbors and the
+// firmware reported the accepted prefixes for all of them
+func bgpNeighborsHavePrefixCounts(rs []BGPNeighborResponse) bool {
+	found := false
+	for _, r := range rs {
+		for _, peer := range r.Results {
+			if peer.AcceptedPrefixes == nil {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
 }
 
 func bgpStateToNumber(bgpState string) float64 {
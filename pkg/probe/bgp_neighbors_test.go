@@ -58,3 +58,40 @@ func TestBGPNeighborsIPv6(t *testing.T) {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func TestBGPNeighborsIPv4Details(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/router/bgp/neighbors", "testdata/router-bgp-neighbors-v4-details.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeBGPNeighborsIPv4, c, r) {
+		t.Errorf("probeBGPNeighborsIPv4() returned non-success")
+	}
+
+	em := `
+    # HELP fortigate_bgp_neighbor_ipv4_accepted_prefixes Number of prefixes accepted from the BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_accepted_prefixes gauge
+    fortigate_bgp_neighbor_ipv4_accepted_prefixes{neighbor_ip="10.0.0.1",vdom="root"} 120
+    # HELP fortigate_bgp_neighbor_ipv4_advertised_prefixes Number of prefixes advertised to the BGP neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_advertised_prefixes gauge
+    fortigate_bgp_neighbor_ipv4_advertised_prefixes{neighbor_ip="10.0.0.1",vdom="root"} 4
+    # HELP fortigate_bgp_neighbor_ipv4_established_transitions_total Number of times the BGP session has reached the established state
+    # TYPE fortigate_bgp_neighbor_ipv4_established_transitions_total counter
+    fortigate_bgp_neighbor_ipv4_established_transitions_total{neighbor_ip="10.0.0.1",vdom="root"} 3
+    # HELP fortigate_bgp_neighbor_ipv4_info Configured bgp neighbor over ipv4, return state as value (1 - Idle, 2 - Connect, 3 - Active, 4 - Open sent, 5 - Open confirm, 6 - Established)
+    # TYPE fortigate_bgp_neighbor_ipv4_info gauge
+    fortigate_bgp_neighbor_ipv4_info{admin_status="true",local_ip="10.0.0.0",neighbor_ip="10.0.0.1",remote_as="1337",state="Established",vdom="root"} 6
+    # HELP fortigate_bgp_neighbor_ipv4_messages_received_total Number of BGP messages received from the neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_messages_received_total counter
+    fortigate_bgp_neighbor_ipv4_messages_received_total{neighbor_ip="10.0.0.1",vdom="root"} 1520
+    # HELP fortigate_bgp_neighbor_ipv4_messages_sent_total Number of BGP messages sent to the neighbor
+    # TYPE fortigate_bgp_neighbor_ipv4_messages_sent_total counter
+    fortigate_bgp_neighbor_ipv4_messages_sent_total{neighbor_ip="10.0.0.1",vdom="root"} 1498
+    # HELP fortigate_bgp_neighbor_ipv4_uptime_seconds Seconds the BGP session has been in its current state
+    # TYPE fortigate_bgp_neighbor_ipv4_uptime_seconds gauge
+    fortigate_bgp_neighbor_ipv4_uptime_seconds{neighbor_ip="10.0.0.1",vdom="root"} 86400
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
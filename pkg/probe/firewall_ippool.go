@@ -14,8 +14,6 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -37,7 +35,7 @@ type IpPoolResponse struct {
 	Version string            `json:"version"`
 }
 
-func probeFirewallIpPool(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeFirewallIpPool(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		mAvailable = prometheus.NewDesc(
 			"fortigate_ippool_available_ratio",
@@ -92,8 +90,7 @@ func probeFirewallIpPool(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.M
 	var rs []IpPoolResponse
 
 	if err := c.Get("api/v2/monitor/firewall/ippool", "vdom=*", &rs); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -110,5 +107,5 @@ func probeFirewallIpPool(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.M
 		}
 	}
 
-	return m, true
+	return m, nil
 }
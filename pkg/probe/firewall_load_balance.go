@@ -22,10 +22,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeFirewallLoadBalance(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeFirewallLoadBalance(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	if meta.VersionMajor < 6 || (meta.VersionMajor == 6 && meta.VersionMinor < 4) {
 		// not supported version. Before 6.4.0 there is no real_server_id and therefore this will fail
-		return nil, true
+		return nil, nil
 	}
 
 	var (
@@ -101,8 +101,7 @@ func probeFirewallLoadBalance(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 	// Consider implementing pagination to remove this limit of 1000 entries
 	var rs []LoadBalanceResponse
 	if err := c.Get("api/v2/monitor/firewall/load-balance", "vdom=*&start=0&count=1000", &rs); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -158,5 +157,5 @@ func probeFirewallLoadBalance(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 			}
 		}
 	}
-	return m, true
+	return m, nil
 }
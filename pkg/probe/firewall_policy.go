@@ -22,7 +22,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		mHitCount = prometheus.NewDesc(
 			"fortigate_policy_hit_count_total",
@@ -31,39 +31,65 @@ func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 		)
 		mBytes = prometheus.NewDesc(
 			"fortigate_policy_bytes_total",
-			"Number of bytes that has passed through a policy",
-			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
+			"Number of bytes that has passed through a policy, per forwarding path",
+			[]string{"vdom", "protocol", "name", "uuid", "id", "path"}, nil,
 		)
 		mPackets = prometheus.NewDesc(
 			"fortigate_policy_packets_total",
-			"Number of packets that has passed through a policy",
-			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
+			"Number of packets that has passed through a policy, per forwarding path",
+			[]string{"vdom", "protocol", "name", "uuid", "id", "path"}, nil,
 		)
 		mActiveSessions = prometheus.NewDesc(
 			"fortigate_policy_active_sessions",
 			"Number of active sessions for a policy",
 			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
 		)
+		mFirstUsed = prometheus.NewDesc(
+			"fortigate_policy_first_used_timestamp_seconds",
+			"Unix timestamp of when a policy was first used",
+			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
+		)
+		mLastUsed = prometheus.NewDesc(
+			"fortigate_policy_last_used_timestamp_seconds",
+			"Unix timestamp of when a policy was last used",
+			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
+		)
+		mSessionFirstUsed = prometheus.NewDesc(
+			"fortigate_policy_session_first_used_timestamp_seconds",
+			"Unix timestamp of when a session was first created for a policy",
+			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
+		)
+		mSessionLastUsed = prometheus.NewDesc(
+			"fortigate_policy_session_last_used_timestamp_seconds",
+			"Unix timestamp of when a session was last created for a policy",
+			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
+		)
+		mInfo = prometheus.NewDesc(
+			"fortigate_policy_info",
+			"Configuration of a policy",
+			[]string{"vdom", "protocol", "name", "uuid", "id", "action", "status"}, nil,
+		)
 	)
 
 	type pStats struct {
-		ID               int64 `json:"policyid"`
-		UUID             string
-		ActiveSessions   float64 `json:"active_sessions"`
-		Bytes            float64
-		Packets          float64
-		SoftwareBytes    float64 `json:"software_bytes"`
-		SoftwarePackets  float64 `json:"software_packets"`
-		ASICBytes        float64 `json:"asic_bytes"`
-		ASICPackets      float64 `json:"asic_packets"`
-		NTurboBytes      float64 `json:"nturbo_bytes"`
-		NTurboPackets    float64 `json:"nturbo_packets"`
-		HitCount         float64 `json:"hit_count"`
-		SessionCount     float64 `json:"session_count"`
-		SessionLastUsed  float64 `json:"session_last_used"`
-		SessionFirstUsed float64 `json:"session_first_used"`
-		LastUsed         float64 `json:"last_used"`
-		FirstUsed        float64 `json:"first_used"`
+		ID             int64 `json:"policyid"`
+		UUID           string
+		ActiveSessions float64 `json:"active_sessions"`
+		Bytes          float64
+		Packets        float64
+		// The offload split is only reported for policies that have seen traffic
+		SoftwareBytes    *float64 `json:"software_bytes"`
+		SoftwarePackets  *float64 `json:"software_packets"`
+		ASICBytes        *float64 `json:"asic_bytes"`
+		ASICPackets      *float64 `json:"asic_packets"`
+		NTurboBytes      *float64 `json:"nturbo_bytes"`
+		NTurboPackets    *float64 `json:"nturbo_packets"`
+		HitCount         float64  `json:"hit_count"`
+		SessionCount     float64  `json:"session_count"`
+		SessionLastUsed  float64  `json:"session_last_used"`
+		SessionFirstUsed float64  `json:"session_first_used"`
+		LastUsed         float64  `json:"last_used"`
+		FirstUsed        float64  `json:"first_used"`
 	}
 	type policyStats struct {
 		Results []pStats
@@ -75,15 +101,13 @@ func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 
 	// NOTE: ip_version=ipv4 is a no-op if combined policies are not active
 	if err := c.Get("api/v2/monitor/firewall/policy/select", "vdom=*&ip_version=ipv4", &ps4); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	combined := false
 	maj, min, ok := version.ParseVersion(ps4[0].Version)
 	if !ok {
-		log.Printf("Could not parse version number %q", ps4[0].Version)
-		return nil, false
+		return nil, fmt.Errorf("could not parse version number %q", ps4[0].Version)
 	}
 	// If we are at 6.4 or later we use combined policies
 	if maj > 6 || (maj == 6 && min >= 4) {
@@ -92,13 +116,11 @@ func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 
 	if !combined {
 		if err := c.Get("api/v2/monitor/firewall/policy6/select", "vdom=*", &ps6); err != nil {
-			log.Printf("Error: %v", err)
-			return nil, false
+			return nil, err
 		}
 	} else {
 		if err := c.Get("api/v2/monitor/firewall/policy/select", "vdom=*&ip_version=ipv6", &ps6); err != nil {
-			log.Printf("Error: %v", err)
-			return nil, false
+			return nil, err
 		}
 	}
 
@@ -120,13 +142,11 @@ func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 	query := "vdom=*&policyid|name|uuid|action|status"
 
 	if err := c.Get("api/v2/cmdb/firewall/policy", query, &pc); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 	if !combined {
 		if err := c.Get("api/v2/cmdb/firewall/policy6", query, &pc6); err != nil {
-			log.Printf("Error: %v", err)
-			return nil, false
+			return nil, err
 		}
 	}
 
@@ -150,21 +170,54 @@ func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 	process := func(ps *policyStats, s *pStats, pcMap map[string]*pConfig, proto string) []prometheus.Metric {
 		id := fmt.Sprintf("%d", s.ID)
 		name := "Implicit Deny"
+		var cfg *pConfig
 		if s.ID > 0 {
-			c, ok := pcMap[s.UUID]
+			var ok bool
+			cfg, ok = pcMap[s.UUID]
 			if !ok {
 				log.Printf("Warning: Failed to map %q to policy config - this should not happen", s.UUID)
 				name = "<UNKNOWN>"
 			} else {
-				name = c.Name
+				name = cfg.Name
 			}
 		}
 		m := []prometheus.Metric{
 			prometheus.MustNewConstMetric(mHitCount, prometheus.CounterValue, s.HitCount, ps.VDOM, proto, name, s.UUID, id),
-			prometheus.MustNewConstMetric(mBytes, prometheus.CounterValue, s.Bytes, ps.VDOM, proto, name, s.UUID, id),
-			prometheus.MustNewConstMetric(mPackets, prometheus.CounterValue, s.Packets, ps.VDOM, proto, name, s.UUID, id),
 			prometheus.MustNewConstMetric(mActiveSessions, prometheus.GaugeValue, s.ActiveSessions, ps.VDOM, proto, name, s.UUID, id),
 		}
+		if s.SoftwareBytes != nil || s.ASICBytes != nil || s.NTurboBytes != nil {
+			m = append(m,
+				prometheus.MustNewConstMetric(mBytes, prometheus.CounterValue, valueOrZero(s.SoftwareBytes), ps.VDOM, proto, name, s.UUID, id, "software"),
+				prometheus.MustNewConstMetric(mBytes, prometheus.CounterValue, valueOrZero(s.ASICBytes), ps.VDOM, proto, name, s.UUID, id, "asic"),
+				prometheus.MustNewConstMetric(mBytes, prometheus.CounterValue, valueOrZero(s.NTurboBytes), ps.VDOM, proto, name, s.UUID, id, "nturbo"),
+				prometheus.MustNewConstMetric(mPackets, prometheus.CounterValue, valueOrZero(s.SoftwarePackets), ps.VDOM, proto, name, s.UUID, id, "software"),
+				prometheus.MustNewConstMetric(mPackets, prometheus.CounterValue, valueOrZero(s.ASICPackets), ps.VDOM, proto, name, s.UUID, id, "asic"),
+				prometheus.MustNewConstMetric(mPackets, prometheus.CounterValue, valueOrZero(s.NTurboPackets), ps.VDOM, proto, name, s.UUID, id, "nturbo"),
+			)
+		} else {
+			// Without the split we cannot tell which path the traffic took
+			m = append(m,
+				prometheus.MustNewConstMetric(mBytes, prometheus.CounterValue, s.Bytes, ps.VDOM, proto, name, s.UUID, id, "unknown"),
+				prometheus.MustNewConstMetric(mPackets, prometheus.CounterValue, s.Packets, ps.VDOM, proto, name, s.UUID, id, "unknown"),
+			)
+		}
+		// Timestamps are 0 for policies that have never been used
+		for _, t := range []struct {
+			desc  *prometheus.Desc
+			value float64
+		}{
+			{mFirstUsed, s.FirstUsed},
+			{mLastUsed, s.LastUsed},
+			{mSessionFirstUsed, s.SessionFirstUsed},
+			{mSessionLastUsed, s.SessionLastUsed},
+		} {
+			if t.value > 0 {
+				m = append(m, prometheus.MustNewConstMetric(t.desc, prometheus.GaugeValue, t.value, ps.VDOM, proto, name, s.UUID, id))
+			}
+		}
+		if cfg != nil {
+			m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, 1, ps.VDOM, proto, name, s.UUID, id, cfg.Action, cfg.Status))
+		}
 		return m
 	}
 
@@ -181,5 +234,12 @@ func probeFirewallPolicies(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 		}
 	}
 
-	return m, true
+	return m, nil
+}
+
+func valueOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
 }
@@ -42,15 +42,21 @@ func TestFirewallPoliciesPre64(t *testing.T) {
 	fortigate_policy_active_sessions{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 2
 	fortigate_policy_active_sessions{id="1",name="ipv6 policy",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
 	fortigate_policy_active_sessions{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
-	# HELP fortigate_policy_bytes_total Number of bytes that has passed through a policy
+	# HELP fortigate_policy_bytes_total Number of bytes that has passed through a policy, per forwarding path
 	# TYPE fortigate_policy_bytes_total counter
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 64687125982
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="root"} 0
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="root"} 432
-	fortigate_policy_bytes_total{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5.34459022e+08
-	fortigate_policy_bytes_total{id="1",name="ipv6 policy",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
-	fortigate_policy_bytes_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="FG-traffic"} 6.4687125982e+10
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="root"} 432
+	fortigate_policy_bytes_total{id="1",name="",path="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5.10815705e+08
+	fortigate_policy_bytes_total{id="1",name="",path="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_bytes_total{id="1",name="",path="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 2.3643317e+07
+	fortigate_policy_bytes_total{id="1",name="ipv6 policy",path="unknown",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_bytes_total{id="2",name="ping",path="unknown",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	# HELP fortigate_policy_first_used_timestamp_seconds Unix timestamp of when a policy was first used
+	# TYPE fortigate_policy_first_used_timestamp_seconds gauge
+	fortigate_policy_first_used_timestamp_seconds{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="root"} 1.590238909e+09
+	fortigate_policy_first_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.589924891e+09
 	# HELP fortigate_policy_hit_count_total Number of times a policy has been hit
 	# TYPE fortigate_policy_hit_count_total counter
 	fortigate_policy_hit_count_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
@@ -60,15 +66,32 @@ func TestFirewallPoliciesPre64(t *testing.T) {
 	fortigate_policy_hit_count_total{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 4662
 	fortigate_policy_hit_count_total{id="1",name="ipv6 policy",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
 	fortigate_policy_hit_count_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
-	# HELP fortigate_policy_packets_total Number of packets that has passed through a policy
+	# HELP fortigate_policy_info Configuration of a policy
+	# TYPE fortigate_policy_info gauge
+	fortigate_policy_info{action="accept",id="1",name="ipv6 policy",protocol="ipv6",status="enable",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 1
+	fortigate_policy_info{action="accept",id="2",name="ping",protocol="ipv4",status="enable",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 1
+	fortigate_policy_info{action="deny",id="1",name="",protocol="ipv4",status="enable",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1
+	# HELP fortigate_policy_last_used_timestamp_seconds Unix timestamp of when a policy was last used
+	# TYPE fortigate_policy_last_used_timestamp_seconds gauge
+	fortigate_policy_last_used_timestamp_seconds{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="root"} 1.590243001e+09
+	fortigate_policy_last_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.590515027e+09
+	# HELP fortigate_policy_packets_total Number of packets that has passed through a policy, per forwarding path
 	# TYPE fortigate_policy_packets_total counter
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="root"} 0
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="root"} 6
-	fortigate_policy_packets_total{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 792806
-	fortigate_policy_packets_total{id="1",name="ipv6 policy",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
-	fortigate_policy_packets_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="root"} 6
+	fortigate_policy_packets_total{id="1",name="",path="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 706553
+	fortigate_policy_packets_total{id="1",name="",path="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="1",name="",path="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 86253
+	fortigate_policy_packets_total{id="1",name="ipv6 policy",path="unknown",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="2",name="ping",path="unknown",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	# HELP fortigate_policy_session_first_used_timestamp_seconds Unix timestamp of when a session was first created for a policy
+	# TYPE fortigate_policy_session_first_used_timestamp_seconds gauge
+	fortigate_policy_session_first_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.589924891e+09
+	# HELP fortigate_policy_session_last_used_timestamp_seconds Unix timestamp of when a session was last created for a policy
+	# TYPE fortigate_policy_session_last_used_timestamp_seconds gauge
+	fortigate_policy_session_last_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.590515027e+09
 	`
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
 		t.Fatalf("metric compare: err %v", err)
@@ -96,16 +119,24 @@ func TestFirewallPolicies(t *testing.T) {
 	fortigate_policy_active_sessions{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 10
 	fortigate_policy_active_sessions{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
 	fortigate_policy_active_sessions{id="2",name="ping",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 1
-	# HELP fortigate_policy_bytes_total Number of bytes that has passed through a policy
+	# HELP fortigate_policy_bytes_total Number of bytes that has passed through a policy, per forwarding path
 	# TYPE fortigate_policy_bytes_total counter
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="root"} 0
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="FG-traffic"} 1
-	fortigate_policy_bytes_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="root"} 0
-	fortigate_policy_bytes_total{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5.34459022e+08
-	fortigate_policy_bytes_total{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1000
-	fortigate_policy_bytes_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
-	fortigate_policy_bytes_total{id="2",name="ping",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 2
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="FG-traffic"} 1
+	fortigate_policy_bytes_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_bytes_total{id="1",name="",path="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5.10815705e+08
+	fortigate_policy_bytes_total{id="1",name="",path="asic",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5000
+	fortigate_policy_bytes_total{id="1",name="",path="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_bytes_total{id="1",name="",path="nturbo",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 7000
+	fortigate_policy_bytes_total{id="1",name="",path="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 2.3643317e+07
+	fortigate_policy_bytes_total{id="1",name="",path="software",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 3000
+	fortigate_policy_bytes_total{id="2",name="ping",path="unknown",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_bytes_total{id="2",name="ping",path="unknown",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 2
+	# HELP fortigate_policy_first_used_timestamp_seconds Unix timestamp of when a policy was first used
+	# TYPE fortigate_policy_first_used_timestamp_seconds gauge
+	fortigate_policy_first_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.589924891e+09
+	fortigate_policy_first_used_timestamp_seconds{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 10000
 	# HELP fortigate_policy_hit_count_total Number of times a policy has been hit
 	# TYPE fortigate_policy_hit_count_total counter
 	fortigate_policy_hit_count_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
@@ -116,16 +147,38 @@ func TestFirewallPolicies(t *testing.T) {
 	fortigate_policy_hit_count_total{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 11000
 	fortigate_policy_hit_count_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
 	fortigate_policy_hit_count_total{id="2",name="ping",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
-	# HELP fortigate_policy_packets_total Number of packets that has passed through a policy
+	# HELP fortigate_policy_info Configuration of a policy
+	# TYPE fortigate_policy_info gauge
+	fortigate_policy_info{action="accept",id="2",name="ping",protocol="ipv4",status="enable",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 1
+	fortigate_policy_info{action="accept",id="2",name="ping",protocol="ipv6",status="enable",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 1
+	fortigate_policy_info{action="deny",id="1",name="",protocol="ipv4",status="enable",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1
+	fortigate_policy_info{action="deny",id="1",name="",protocol="ipv6",status="enable",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1
+	# HELP fortigate_policy_last_used_timestamp_seconds Unix timestamp of when a policy was last used
+	# TYPE fortigate_policy_last_used_timestamp_seconds gauge
+	fortigate_policy_last_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.590515027e+09
+	fortigate_policy_last_used_timestamp_seconds{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 9000
+	# HELP fortigate_policy_packets_total Number of packets that has passed through a policy, per forwarding path
 	# TYPE fortigate_policy_packets_total counter
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="root"} 0
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="FG-traffic"} 1
-	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv6",uuid="",vdom="root"} 0
-	fortigate_policy_packets_total{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 792806
-	fortigate_policy_packets_total{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 2000
-	fortigate_policy_packets_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
-	fortigate_policy_packets_total{id="2",name="ping",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 3
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="FG-traffic"} 1
+	fortigate_policy_packets_total{id="0",name="Implicit Deny",path="unknown",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_packets_total{id="1",name="",path="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 706553
+	fortigate_policy_packets_total{id="1",name="",path="asic",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 6000
+	fortigate_policy_packets_total{id="1",name="",path="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="1",name="",path="nturbo",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 8000
+	fortigate_policy_packets_total{id="1",name="",path="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 86253
+	fortigate_policy_packets_total{id="1",name="",path="software",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 4000
+	fortigate_policy_packets_total{id="2",name="ping",path="unknown",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_packets_total{id="2",name="ping",path="unknown",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 3
+	# HELP fortigate_policy_session_first_used_timestamp_seconds Unix timestamp of when a session was first created for a policy
+	# TYPE fortigate_policy_session_first_used_timestamp_seconds gauge
+	fortigate_policy_session_first_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.589924891e+09
+	fortigate_policy_session_first_used_timestamp_seconds{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 13000
+	# HELP fortigate_policy_session_last_used_timestamp_seconds Unix timestamp of when a session was last created for a policy
+	# TYPE fortigate_policy_session_last_used_timestamp_seconds gauge
+	fortigate_policy_session_last_used_timestamp_seconds{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 1.590515027e+09
+	fortigate_policy_session_last_used_timestamp_seconds{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 12000
 	`
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
 		t.Fatalf("metric compare: err %v", err)
@@ -0,0 +1,161 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	fortiHTTP "github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+// haMemberOptions are the settings of -ha-members
+type haMemberOptions struct {
+	// probes are the prefixes of the probes run against every member
+	probes []string
+	// peerParam is the query parameter that makes the primary forward a
+	// request to the member with the given serial
+	peerParam string
+}
+
+// haMemberOptionsFrom returns the HA member settings, nil if -ha-members is not set
+func haMemberOptionsFrom(cfg config.FortiExporterConfig) *haMemberOptions {
+	if !cfg.HAMembers {
+		return nil
+	}
+	return &haMemberOptions{probes: cfg.HAMemberProbes, peerParam: cfg.HAMemberParam}
+}
+
+// haPeerClient sends every request to one secondary member of an HA cluster
+type haPeerClient struct {
+	fortiHTTP.FortiHTTP
+	param  string
+	serial string
+}
+
+func (c *haPeerClient) Get(path string, query string, obj interface{}) error {
+	peer := c.param + "=" + url.QueryEscape(c.serial)
+	if query != "" {
+		peer = query + "&" + peer
+	}
+	return c.FortiHTTP.Get(path, peer, obj)
+}
+
+// checkHAPeer makes sure the primary forwards requests to the member, a
+// firmware ignoring the parameter would answer itself and have its data
+// reported for the member
+func checkHAPeer(c fortiHTTP.FortiHTTP, serial string) error {
+	var st struct {
+		Serial string
+	}
+	if err := c.Get("api/v2/monitor/system/status", "", &st); err != nil {
+		// Not wrapped, the probes must fail even if the endpoint is missing
+		return fmt.Errorf("failed to verify HA member %s: %v", serial, err)
+	}
+	if st.Serial != serial {
+		return fmt.Errorf("request for HA member %s was answered by %s", serial, st.Serial)
+	}
+	return nil
+}
+
+// splitProbes moves the probes matching any of the prefixes to the second list
+func splitProbes(probes []probeDetailedFunc, prefixes []string) ([]probeDetailedFunc, []probeDetailedFunc) {
+	var rest, matched []probeDetailedFunc
+	for _, aProbe := range probes {
+		match := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(aProbe.name, prefix) {
+				match = true
+				break
+			}
+		}
+		if match {
+			matched = append(matched, aProbe)
+		} else {
+			rest = append(rest, aProbe)
+		}
+	}
+	return rest, matched
+}
+
+// probeHAMembers runs the probes against every HA cluster member, the primary
+// is queried directly and the secondaries through it. A standalone unit is
+// reported as a cluster of one with the serial from the status call.
+func (p *ProbeCollector) probeHAMembers(c fortiHTTP.FortiHTTP, meta *TargetMetadata, serial string, probes []probeDetailedFunc, peerParam string, concurrency int) bool {
+	success := true
+	primary := serial
+	var secondaries []string
+
+	var res HAChecksum
+	if err := c.Get("api/v2/monitor/system/ha-checksums", "scope=global", &res); err != nil {
+		if !fortiHTTP.IsNotFound(err) {
+			log.Printf("Error: failed to list HA cluster members: %v", err)
+			success = false
+		}
+	} else {
+		for _, r := range res.Results {
+			if r.IsManageMaster == 1 {
+				primary = r.SerialNo
+			}
+		}
+		for _, r := range res.Results {
+			if r.SerialNo != primary {
+				secondaries = append(secondaries, r.SerialNo)
+			}
+		}
+	}
+
+	members := map[string]*ProbeCollector{}
+	// The primary goes first so that its first probe runs before any other
+	for _, s := range append([]string{primary}, secondaries...) {
+		members[s] = &ProbeCollector{target: p.target, haMember: true}
+		mc := c
+		if s != primary {
+			mc = &haPeerClient{FortiHTTP: c, param: peerParam, serial: s}
+			if err := checkHAPeer(mc, s); err != nil {
+				log.Printf("Error: skipping probes of HA member %s: %v", s, err)
+				results := make([]probeResult, len(probes))
+				for i := range results {
+					results[i].err = err
+				}
+				members[s].addResults(probes, results)
+				success = false
+				continue
+			}
+		}
+		if !members[s].addResults(probes, runProbes(mc, meta, probes, concurrency)) {
+			success = false
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.members = members
+	return success
+}
+
+// Members returns the collectors of the HA cluster members keyed by serial,
+// the handler registers them with an ha_serial label
+func (p *ProbeCollector) Members() map[string]*ProbeCollector {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	members := make(map[string]*ProbeCollector, len(p.members))
+	for s, m := range p.members {
+		members[s] = m
+	}
+	return members
+}
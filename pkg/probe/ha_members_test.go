@@ -0,0 +1,178 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestHAMembers(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/ha-checksums", "testdata/ha-checksum.jsonnet")
+	c.prepare("api/v2/monitor/system/status?serial=SERIAL222222222", "testdata/status-peer.jsonnet")
+	c.prepare("api/v2/monitor/system/time?serial=SERIAL222222222", "testdata/system-time-peer.jsonnet")
+	c.prepare("api/v2/monitor/system/time", "testdata/system-time.jsonnet")
+
+	probes := []probeDetailedFunc{
+		{"System/Status", probeSystemStatus},
+		{"System/Time/Clock", probeSystemTime},
+	}
+	rest, memberProbes := splitProbes(probes, []string{"System/Time"})
+	if len(rest) != 1 || rest[0].name != "System/Status" || len(memberProbes) != 1 {
+		t.Fatalf("splitProbes() returned %d and %d probes", len(rest), len(memberProbes))
+	}
+
+	p := &ProbeCollector{}
+	meta := &TargetMetadata{VersionMajor: 7, VersionMinor: 0}
+	if !p.probeHAMembers(c, meta, "FGT61FT000000000", memberProbes, "serial", 1) {
+		t.Errorf("probeHAMembers() returned non-success")
+	}
+
+	r := prometheus.NewPedanticRegistry()
+	for serial, mc := range p.Members() {
+		prometheus.WrapRegistererWith(prometheus.Labels{"ha_serial": serial}, r).MustRegister(mc)
+	}
+
+	em := `
+	# HELP fortigate_time_seconds System epoch time in seconds
+	# TYPE fortigate_time_seconds gauge
+	fortigate_time_seconds{ha_serial="SERIAL111111111"} 1.630313596e+09
+	fortigate_time_seconds{ha_serial="SERIAL222222222"} 1.630313597e+09
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_time_seconds"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestHAMembersTimeFirst(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/ha-checksums", "testdata/ha-checksum.jsonnet")
+	c.prepare("api/v2/monitor/system/status?serial=SERIAL222222222", "testdata/status-peer.jsonnet")
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) probeFunc {
+		return func(http.FortiHTTP, *TargetMetadata) ([]prometheus.Metric, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil, nil
+		}
+	}
+	probes := []probeDetailedFunc{
+		{"System/Time/Clock", record("time")},
+		{"System/Status", record("status")},
+		{"System/SensorInfo", record("sensors")},
+	}
+
+	p := &ProbeCollector{}
+	meta := &TargetMetadata{VersionMajor: 7, VersionMinor: 0}
+	if !p.runAll(c, meta, "FGT61FT000000000", probes, &haMemberOptions{probes: []string{"System/Time", "System/SensorInfo"}, peerParam: "serial"}, 2) {
+		t.Errorf("runAll() returned non-success")
+	}
+	if len(order) != 5 || order[0] != "time" {
+		t.Errorf("runAll() ran %v, expected the time probe first", order)
+	}
+}
+
+func TestHAMembersRegistry(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/ha-checksums", "testdata/ha-checksum.jsonnet")
+	c.prepare("api/v2/monitor/system/status?serial=SERIAL222222222", "testdata/status-peer.jsonnet")
+	c.prepare("api/v2/monitor/system/time?serial=SERIAL222222222", "testdata/system-time-peer.jsonnet")
+	c.prepare("api/v2/monitor/system/time", "testdata/system-time.jsonnet")
+	c.prepare("api/v2/monitor/system/status", "testdata/status.jsonnet")
+
+	probes := []probeDetailedFunc{
+		{"System/Time/Clock", probeSystemTime},
+		{"System/Status", probeSystemStatus},
+	}
+	p := &ProbeCollector{}
+	meta := &TargetMetadata{VersionMajor: 7, VersionMinor: 0}
+	if !p.runAll(c, meta, "FGT61FT000000000", probes, &haMemberOptions{probes: []string{"System/Time"}, peerParam: "serial"}, 1) {
+		t.Errorf("runAll() returned non-success")
+	}
+
+	// Same setup as ProbeHandler
+	r := prometheus.NewRegistry()
+	r.MustRegister(p)
+	registerMembers(r, p)
+
+	mfs, err := r.Gather()
+	if err != nil {
+		t.Fatalf("Gather() failed: %v", err)
+	}
+	for _, mf := range mfs {
+		labels := -1
+		for _, m := range mf.GetMetric() {
+			if labels >= 0 && len(m.GetLabel()) != labels {
+				t.Errorf("metric family %s mixes label sets", mf.GetName())
+			}
+			labels = len(m.GetLabel())
+		}
+	}
+
+	em := `
+	# HELP fortigate_exporter_probe_success Whether or not the probe succeeded, per probe
+	# TYPE fortigate_exporter_probe_success gauge
+	fortigate_exporter_probe_success{probe="System/Status"} 1
+	# HELP fortigate_exporter_ha_member_probe_success Whether or not the probe succeeded, per HA cluster member and probe
+	# TYPE fortigate_exporter_ha_member_probe_success gauge
+	fortigate_exporter_ha_member_probe_success{ha_serial="SERIAL111111111",probe="System/Time/Clock"} 1
+	fortigate_exporter_ha_member_probe_success{ha_serial="SERIAL222222222",probe="System/Time/Clock"} 1
+	`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_exporter_probe_success", "fortigate_exporter_ha_member_probe_success"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestHAMembersPeerNotForwarded(t *testing.T) {
+	c := newFakeClient()
+	// The firmware ignores the serial parameter, the primary answers every request
+	c.prepare("api/v2/monitor/system/ha-checksums", "testdata/ha-checksum.jsonnet")
+	c.prepare("api/v2/monitor/system/status", "testdata/status.jsonnet")
+	c.prepare("api/v2/monitor/system/time", "testdata/system-time.jsonnet")
+
+	probes := []probeDetailedFunc{{"System/Time/Clock", probeSystemTime}}
+	p := &ProbeCollector{}
+	meta := &TargetMetadata{VersionMajor: 7, VersionMinor: 0}
+	if p.probeHAMembers(c, meta, "FGT61FT000000000", probes, "serial", 1) {
+		t.Errorf("probeHAMembers() returned success for a member that was not reached")
+	}
+
+	r := prometheus.NewPedanticRegistry()
+	for serial, mc := range p.Members() {
+		prometheus.WrapRegistererWith(prometheus.Labels{"ha_serial": serial}, r).MustRegister(mc)
+	}
+
+	em := `
+	# HELP fortigate_exporter_ha_member_probe_success Whether or not the probe succeeded, per HA cluster member and probe
+	# TYPE fortigate_exporter_ha_member_probe_success gauge
+	fortigate_exporter_ha_member_probe_success{ha_serial="SERIAL111111111",probe="System/Time/Clock"} 1
+	fortigate_exporter_ha_member_probe_success{ha_serial="SERIAL222222222",probe="System/Time/Clock"} 0
+	# HELP fortigate_time_seconds System epoch time in seconds
+	# TYPE fortigate_time_seconds gauge
+	fortigate_time_seconds{ha_serial="SERIAL111111111"} 1.630313596e+09
+	`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_exporter_ha_member_probe_success", "fortigate_time_seconds"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
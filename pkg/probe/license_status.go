@@ -14,13 +14,11 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeLicenseStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeLicenseStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		vdomUsed = prometheus.NewDesc(
 			"fortigate_license_vdom_usage",
@@ -49,8 +47,7 @@ func probeLicenseStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Me
 	var r LicenseResponse
 
 	if err := c.Get("api/v2/monitor/license/status/select", "", &r); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{
@@ -58,5 +55,5 @@ func probeLicenseStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Me
 		prometheus.MustNewConstMetric(vdomMax, prometheus.GaugeValue, float64(r.Results.VDOM.Max)),
 	}
 
-	return m, true
+	return m, nil
 }
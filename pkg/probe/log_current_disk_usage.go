@@ -14,8 +14,6 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -30,7 +28,7 @@ type Log struct {
 	VDOM    string     `json:"vdom"`
 }
 
-func probeLogCurrentDiskUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeLogCurrentDiskUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		logUsed = prometheus.NewDesc(
 			"fortigate_log_disk_used_bytes",
@@ -46,8 +44,7 @@ func probeLogCurrentDiskUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 
 	var res []Log
 	if err := c.Get("api/v2/monitor/log/current-disk-usage", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -56,5 +53,5 @@ func probeLogCurrentDiskUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 		m = append(m, prometheus.MustNewConstMetric(logTotal, prometheus.GaugeValue, r.Results.TotalBytes, r.VDOM))
 	}
 
-	return m, true
+	return m, nil
 }
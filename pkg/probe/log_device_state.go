@@ -0,0 +1,67 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type LogDeviceState struct {
+	IsAvailable bool `json:"is_available"`
+	IsEnabled   bool `json:"is_enabled"`
+}
+
+type LogDeviceStates struct {
+	Results map[string]LogDeviceState `json:"results"`
+	VDOM    string                    `json:"vdom"`
+}
+
+func probeLogDeviceState(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	var (
+		logDevEnabled = prometheus.NewDesc(
+			"fortigate_log_device_enabled",
+			"Whether logging to the log device is enabled",
+			[]string{"vdom", "device"}, nil,
+		)
+		logDevAvailable = prometheus.NewDesc(
+			"fortigate_log_device_available",
+			"Whether the log device is available",
+			[]string{"vdom", "device"}, nil,
+		)
+	)
+
+	var res []LogDeviceStates
+	if err := c.Get("api/v2/monitor/log/device/state", "vdom=*", &res); err != nil {
+		return nil, err
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range res {
+		for device, s := range r.Results {
+			enabled := 0.0
+			if s.IsEnabled {
+				enabled = 1.0
+			}
+			available := 0.0
+			if s.IsAvailable {
+				available = 1.0
+			}
+			m = append(m, prometheus.MustNewConstMetric(logDevEnabled, prometheus.GaugeValue, enabled, r.VDOM, device))
+			m = append(m, prometheus.MustNewConstMetric(logDevAvailable, prometheus.GaugeValue, available, r.VDOM, device))
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLogDeviceState(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/log/device/state", "testdata/log-device-state.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeLogDeviceState, c, r) {
+		t.Errorf("probeLogDeviceState() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_log_device_available Whether the log device is available
+	# TYPE fortigate_log_device_available gauge
+	fortigate_log_device_available{device="disk",vdom="FG-traffic"} 1
+	fortigate_log_device_available{device="disk",vdom="root"} 1
+	fortigate_log_device_available{device="fortianalyzer",vdom="FG-traffic"} 1
+	fortigate_log_device_available{device="fortianalyzer",vdom="root"} 1
+	fortigate_log_device_available{device="forticloud",vdom="FG-traffic"} 0
+	fortigate_log_device_available{device="forticloud",vdom="root"} 0
+	fortigate_log_device_available{device="memory",vdom="FG-traffic"} 1
+	fortigate_log_device_available{device="memory",vdom="root"} 1
+	# HELP fortigate_log_device_enabled Whether logging to the log device is enabled
+	# TYPE fortigate_log_device_enabled gauge
+	fortigate_log_device_enabled{device="disk",vdom="FG-traffic"} 1
+	fortigate_log_device_enabled{device="disk",vdom="root"} 1
+	fortigate_log_device_enabled{device="fortianalyzer",vdom="FG-traffic"} 0
+	fortigate_log_device_enabled{device="fortianalyzer",vdom="root"} 0
+	fortigate_log_device_enabled{device="forticloud",vdom="FG-traffic"} 0
+	fortigate_log_device_enabled{device="forticloud",vdom="root"} 0
+	fortigate_log_device_enabled{device="memory",vdom="FG-traffic"} 0
+	fortigate_log_device_enabled{device="memory",vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
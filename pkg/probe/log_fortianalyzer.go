@@ -14,8 +14,6 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -31,7 +29,7 @@ type LogAna struct {
 	VDOM    string        `json:"vdom"`
 }
 
-func probeLogAnalyzer(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeLogAnalyzer(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		logAnaInfo = prometheus.NewDesc(
 			"fortigate_log_fortianalyzer_registration_info",
@@ -47,8 +45,7 @@ func probeLogAnalyzer(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metr
 
 	var res []LogAna
 	if err := c.Get("api/v2/monitor/log/fortianalyzer", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -57,5 +54,5 @@ func probeLogAnalyzer(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metr
 		m = append(m, prometheus.MustNewConstMetric(logAnaRcv, prometheus.GaugeValue, r.Results.Received, r.VDOM))
 	}
 
-	return m, true
+	return m, nil
 }
@@ -14,8 +14,6 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -31,7 +29,7 @@ type LogAnaQueue struct {
 	VDOM    string             `json:"vdom"`
 }
 
-func probeLogAnalyzerQueue(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeLogAnalyzerQueue(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		logAnaConn = prometheus.NewDesc(
 			"fortigate_log_fortianalyzer_queue_connections",
@@ -47,8 +45,7 @@ func probeLogAnalyzerQueue(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 
 	var res []LogAnaQueue
 	if err := c.Get("api/v2/monitor/log/fortianalyzer-queue", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -62,5 +59,5 @@ func probeLogAnalyzerQueue(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 		m = append(m, prometheus.MustNewConstMetric(logAnaLogs, prometheus.GaugeValue, r.Results.CachedLogs, r.VDOM, "cached"))
 	}
 
-	return m, true
+	return m, nil
 }
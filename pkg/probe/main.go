@@ -43,6 +43,19 @@ func ProbeHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Target parameter missing or empty", http.StatusBadRequest)
 		return
 	}
+
+	timeout := savedConfig.ScrapeTimeout
+	if name := params.Get("module"); name != "" {
+		module, ok := savedConfig.Modules[name]
+		if !ok {
+			http.Error(w, fmt.Sprintf("Unknown module %q", name), http.StatusBadRequest)
+			return
+		}
+		paramMap["module"] = name
+		if module.Timeout > 0 {
+			timeout = module.Timeout
+		}
+	}
 	probeSuccessGauge := prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "probe_success",
 		Help: "Whether or not the probe succeeded",
@@ -51,7 +64,7 @@ func ProbeHandler(w http.ResponseWriter, r *http.Request) {
 		Name: "probe_duration_seconds",
 		Help: "How many seconds the probe took to complete",
 	})
-	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(savedConfig.ScrapeTimeout)*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(timeout)*time.Second)
 	defer cancel()
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(probeSuccessGauge)
@@ -65,6 +78,7 @@ func ProbeHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("probe: %v", err), http.StatusBadRequest)
 		return
 	}
+	registerMembers(registry, pc)
 	duration := time.Since(start).Seconds()
 	probeDurationGauge.Set(duration)
 	if success {
@@ -77,3 +91,11 @@ func ProbeHandler(w http.ResponseWriter, r *http.Request) {
 	h := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 	h.ServeHTTP(w, r)
 }
+
+// registerMembers adds the collectors of the HA cluster members, with an
+// ha_serial label
+func registerMembers(r prometheus.Registerer, pc *ProbeCollector) {
+	for serial, mc := range pc.Members() {
+		prometheus.WrapRegistererWith(prometheus.Labels{"ha_serial": serial}, r).MustRegister(mc)
+	}
+}
@@ -14,7 +14,6 @@
 package probe
 
 import (
-	"log"
 	"strconv"
 
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
@@ -34,10 +33,10 @@ type OSPFNeighborResponse struct {
 	Version string         `json:"version"`
 }
 
-func probeOSPFNeighbors(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeOSPFNeighbors(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	if meta.VersionMajor < 7 {
 		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
-		return nil, true
+		return nil, nil
 	}
 	var (
 		mOSPFNeighbor = prometheus.NewDesc(
@@ -50,8 +49,7 @@ func probeOSPFNeighbors(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Me
 	var rs []OSPFNeighborResponse
 
 	if err := c.Get("api/v2/monitor/router/ospf/neighbors", "vdom=*", &rs); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -62,7 +60,7 @@ func probeOSPFNeighbors(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Me
 		}
 	}
 
-	return m, true
+	return m, nil
 }
 
 func ospfStateToNumber(ospfState string) float64 {
@@ -32,34 +32,93 @@ package probe
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/internal/version"
 	fortiHTTP "github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+var (
+	mProbeSuccess = prometheus.NewDesc(
+		"fortigate_exporter_probe_success",
+		"Whether or not the probe succeeded, per probe",
+		[]string{"probe"}, nil,
+	)
+	mProbeDuration = prometheus.NewDesc(
+		"fortigate_exporter_probe_duration_seconds",
+		"How many seconds the probe took to complete, per probe",
+		[]string{"probe"}, nil,
+	)
+
+	// The HA member collectors are exported with an ha_serial label, their probe
+	// results need separate families to keep the label sets consistent
+	mHAMemberProbeSuccess = prometheus.NewDesc(
+		"fortigate_exporter_ha_member_probe_success",
+		"Whether or not the probe succeeded, per HA cluster member and probe",
+		[]string{"probe"}, nil,
+	)
+	mHAMemberProbeDuration = prometheus.NewDesc(
+		"fortigate_exporter_ha_member_probe_duration_seconds",
+		"How many seconds the probe took to complete, per HA cluster member and probe",
+		[]string{"probe"}, nil,
+	)
+
+	probeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fortigate_exporter_probe_failures_total",
+		Help: "Number of failed probes, per target, probe and error class",
+	}, []string{"target", "probe", "class"})
+)
+
+// ForgetTarget drops the failure counts of a target, it is called when a
+// dynamic target is evicted so their series do not pile up
+func ForgetTarget(t config.Target) {
+	probeFailures.DeletePartialMatch(prometheus.Labels{"target": string(t)})
+}
+
 type ProbeCollector struct {
-	metrics []prometheus.Metric
+	// target is used to label the failures counted in probeFailures
+	target string
+	// haMember is set for the collectors of HA cluster members
+	haMember bool
+	mu       sync.Mutex
+	metrics  []prometheus.Metric
+	// members holds the results of the HA member probes, keyed by serial
+	members map[string]*ProbeCollector
 }
 
 type TargetMetadata struct {
 	VersionMajor int
 	VersionMinor int
+	// Module selected with the module= parameter, nil if none
+	Module *config.Module
 }
 
-type probeFunc func(fortiHTTP.FortiHTTP, *TargetMetadata) ([]prometheus.Metric, bool)
+type probeFunc func(fortiHTTP.FortiHTTP, *TargetMetadata) ([]prometheus.Metric, error)
 
 type probeDetailedFunc struct {
 	name     string
 	function probeFunc
 }
 
+type probeResult struct {
+	metrics  []prometheus.Metric
+	err      error
+	duration time.Duration
+}
+
 func (p *ProbeCollector) Probe(ctx context.Context, target map[string]string, hc *http.Client, savedConfig config.FortiExporterConfig) (bool, error) {
 	tgt, err := url.Parse(target["target"])
 	if err != nil {
@@ -76,20 +135,29 @@ func (p *ProbeCollector) Probe(ctx context.Context, target map[string]string, hc
 		Host:   tgt.Host,
 	}
 
-	if target["token"] != "" && savedConfig.AuthKeys[config.Target(target["target"])].Token == "" {
-		// Add the target and its apikey to the savedConfig and use, if exists, a target entry as a template for include/exclude
-		// This will only happened the "first" time
-		savedConfig.AuthKeys[config.Target(target["target"])] = config.TargetAuth{Token: config.Token(target["token"]),
-			Probes: savedConfig.AuthKeys[config.Target(target["profile"])].Probes}
+	p.target = u.String()
+
+	auth, err := lookupAuth(config.Target(u.String()), target, savedConfig)
+	if err != nil {
+		return false, err
 	}
 
-	c, err := fortiHTTP.NewFortiClient(ctx, u, hc, savedConfig)
+	c, err := fortiHTTP.NewFortiClient(ctx, u, hc, auth)
 	if err != nil {
 		return false, err
 	}
+	if cl, ok := c.(io.Closer); ok {
+		// Session based clients have to log out again
+		defer func() {
+			if err := cl.Close(); err != nil {
+				log.Printf("Warning: failed to close API session to %q: %v", u.String(), err)
+			}
+		}()
+	}
 
 	type systemStatus struct {
 		Status  string
+		Serial  string
 		Version string
 	}
 	var st systemStatus
@@ -98,7 +166,11 @@ func (p *ProbeCollector) Probe(ctx context.Context, target map[string]string, hc
 	// The "system status" group has access group "any" so it is a good source
 	// to test the authentication as well as fetching the OS version.
 	if err := c.Get("api/v2/monitor/system/status", "", &st); err != nil {
-		log.Printf("Error: API connectivity test failed, %v", err)
+		if fortiHTTP.IsUnauthorized(err) {
+			log.Printf("Error: API connectivity test failed, authentication was rejected: %v", err)
+		} else {
+			log.Printf("Error: API connectivity test failed, %v", err)
+		}
 		return false, nil
 	}
 
@@ -118,13 +190,28 @@ func (p *ProbeCollector) Probe(ctx context.Context, target map[string]string, hc
 		VersionMinor: minor,
 	}
 
-	includedProbes := savedConfig.AuthKeys[config.Target(u.String())].Probes.Include
-	excludedProbes := savedConfig.AuthKeys[config.Target(u.String())].Probes.Exclude
+	includedProbes := auth.Probes.Include
+	excludedProbes := auth.Probes.Exclude
 
-	// TODO: Make parallel
-	success := true
+	if name := target["module"]; name != "" {
+		module, ok := savedConfig.Modules[name]
+		if !ok {
+			return false, fmt.Errorf("Unknown module %q", name)
+		}
+		meta.Module = &module
+		includedProbes = module.Probes.Include
+		excludedProbes = module.Probes.Exclude
+	}
+
+	concurrency := savedConfig.ProbeConcurrency
+	if auth.Concurrency > 0 {
+		concurrency = auth.Concurrency
+	}
+
+	probes := []probeDetailedFunc{}
 	for _, aProbe := range []probeDetailedFunc{
-		// Always keep probeSystemTime on top of the list to have the probe processed first.
+		// Always keep probeSystemTime on top of the list to have the probe processed first,
+		// the first wanted probe is run on its own before the others are started in parallel.
 		// Therefore time returned is more accurate when integrated in Prometheus because
 		// timestamp for the metrics probe, in Prometheus, is obtained from the query time, not the reply time.
 		// This is especially important when running all the probes takes many seconds.
@@ -140,9 +227,14 @@ func (p *ProbeCollector) Probe(ctx context.Context, target map[string]string, hc
 		{"Log/Fortianalyzer/Status", probeLogAnalyzer},
 		{"Log/Fortianalyzer/Queue", probeLogAnalyzerQueue},
 		{"Log/DiskUsage", probeLogCurrentDiskUsage},
+		{"Log/Device/State", probeLogDeviceState},
+		{"System/AggregateInterface", probeSystemInterfaceAggregate},
 		{"System/AvailableCertificates", probeSystemAvailableCertificates},
+		{"System/ConfigRevision", probeSystemConfigRevision},
+		{"System/ConserveMode", probeSystemConserveMode},
 		{"System/Fortimanager/Status", probeSystemFortimanagerStatus},
 		{"System/HAStatistics", probeSystemHAStatistics},
+		{"System/HAHistory", probeSystemHAHistory},
 		{"System/Interface", probeSystemInterface},
 		{"System/LinkMonitor", probeSystemLinkMonitor},
 		{"System/Resource/Usage", probeSystemResourceUsage},
@@ -162,6 +254,7 @@ func (p *ProbeCollector) Probe(ctx context.Context, target map[string]string, hc
 		{"Wifi/ManagedAP", probeWifiManagedAP},
 		{"Switch/ManagedSwitch", probeManagedSwitch},
 		{"OSPF/Neighbors", probeOSPFNeighbors},
+		{"Router/Statistics", probeRouterStatistics},
 	} {
 		wanted := false
 
@@ -189,17 +282,207 @@ func (p *ProbeCollector) Probe(ctx context.Context, target map[string]string, hc
 			continue
 		}
 
-		m, ok := aProbe.function(c, meta)
-		if !ok {
+		probes = append(probes, aProbe)
+	}
+
+	return p.runAll(c, meta, st.Serial, probes, haMemberOptionsFrom(savedConfig), concurrency), nil
+}
+
+// runAll runs the probes against the target, if ha is set the probes matching
+// its prefixes are run against every HA cluster member instead. It returns
+// false if any probe failed.
+func (p *ProbeCollector) runAll(c fortiHTTP.FortiHTTP, meta *TargetMetadata, serial string, probes []probeDetailedFunc, ha *haMemberOptions, concurrency int) bool {
+	var memberProbes []probeDetailedFunc
+	if ha != nil {
+		probes, memberProbes = splitProbes(probes, ha.probes)
+	}
+
+	success := true
+	// The member probes run first as they include System/Time/Clock by default,
+	// which has to be run before any other probe on the primary
+	if len(memberProbes) > 0 && !p.probeHAMembers(c, meta, serial, memberProbes, ha.peerParam, concurrency) {
+		success = false
+	}
+	if !p.addResults(probes, runProbes(c, meta, probes, concurrency)) {
+		success = false
+	}
+	return success
+}
+
+// addResults adds the metrics of the probe results, it returns false if any probe failed
+func (p *ProbeCollector) addResults(probes []probeDetailedFunc, results []probeResult) bool {
+	success := true
+	for i, r := range results {
+		name := probes[i].name
+		ok := 1.0
+		switch {
+		case r.err == nil:
+		case fortiHTTP.IsNotFound(r.err):
+			// The endpoint is not present on this firmware or model, nothing to
+			// report but still counted so missing endpoints can be found
+			probeFailures.WithLabelValues(p.target, name, errorClass(r.err)).Inc()
+		default:
+			if fortiHTTP.IsPermissionDenied(r.err) {
+				log.Printf("Error: probe %s failed, token lacks permission: %v", name, r.err)
+			} else {
+				log.Printf("Error: probe %s failed: %v", name, r.err)
+			}
+			probeFailures.WithLabelValues(p.target, name, errorClass(r.err)).Inc()
 			success = false
+			ok = 0.0
+		}
+		p.addMetrics(r.metrics...)
+		mSuccess, mDuration := mProbeSuccess, mProbeDuration
+		if p.haMember {
+			mSuccess, mDuration = mHAMemberProbeSuccess, mHAMemberProbeDuration
 		}
-		p.metrics = append(p.metrics, m...)
+		p.addMetrics(
+			prometheus.MustNewConstMetric(mSuccess, prometheus.GaugeValue, ok, name),
+			prometheus.MustNewConstMetric(mDuration, prometheus.GaugeValue, r.duration.Seconds(), name),
+		)
 	}
+	return success
+}
+
+// maxBGPPaths returns the BGP path limit of the selected module, or -max-bgp-paths if not set
+func maxBGPPaths(meta *TargetMetadata) int {
+	if meta.Module != nil && meta.Module.MaxBGPPaths > 0 {
+		return meta.Module.MaxBGPPaths
+	}
+	return config.GetConfig().MaxBGPPaths
+}
+
+// bgpSkipPaths returns if the BGP paths download may be skipped when the
+// neighbors report their prefix counts, enabled by the selected module or -bgp-skip-paths
+func bgpSkipPaths(meta *TargetMetadata) bool {
+	if meta.Module != nil && meta.Module.BGPSkipPaths {
+		return true
+	}
+	return config.GetConfig().BGPSkipPaths
+}
+
+// maxVPNUsers returns the VPN user limit of the selected module, or -max-vpn-users if not set
+func maxVPNUsers(meta *TargetMetadata) int {
+	if meta.Module != nil && meta.Module.MaxVPNUsers > 0 {
+		return meta.Module.MaxVPNUsers
+	}
+	return config.GetConfig().MaxVPNUsers
+}
+
+// vpnUserDetails returns if the per session SSL-VPN metrics are enabled by the
+// selected module or -vpn-user-details
+func vpnUserDetails(meta *TargetMetadata) bool {
+	if meta.Module != nil && meta.Module.VPNUserDetails {
+		return true
+	}
+	return config.GetConfig().VPNUserDetails
+}
+
+// lookupAuth returns the authentication for a target, either from the auth file
+// or from the token passed as probe parameter.
+func lookupAuth(tgt config.Target, target map[string]string, savedConfig config.FortiExporterConfig) (config.TargetAuth, error) {
+	if auth, ok := savedConfig.AuthKeys[tgt]; ok {
+		return auth, nil
+	}
+
+	dynamicTargets := config.GetDynamicTargets()
+	if !savedConfig.AllowDynamic {
+		return config.TargetAuth{}, fmt.Errorf("no API authentication registered for %q and dynamic targets are not allowed", tgt)
+	}
+
+	if target["token"] != "" {
+		// Use, if exists, the profile entry as a template for include/exclude
+		profile := savedConfig.AuthKeys[config.Target(target["profile"])]
+		auth := config.TargetAuth{
+			Token:       config.Token(target["token"]),
+			Probes:      profile.Probes,
+			Concurrency: profile.Concurrency,
+		}
+		dynamicTargets.Put(tgt, auth)
+		return auth, nil
+	}
+
+	if auth, ok := dynamicTargets.Get(tgt); ok {
+		return auth, nil
+	}
+	return config.TargetAuth{}, fmt.Errorf("no API authentication registered for %q", tgt)
+}
+
+// runProbes runs the probes with at most concurrency probes in flight.
+// The first probe is run on its own before any other probe is started,
+// results are returned in the same order as the probes.
+func runProbes(c fortiHTTP.FortiHTTP, meta *TargetMetadata, probes []probeDetailedFunc, concurrency int) []probeResult {
+	results := make([]probeResult, len(probes))
+	if len(probes) == 0 {
+		return results
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	run := func(i int) {
+		start := time.Now()
+		m, err := probes[i].function(c, meta)
+		results[i] = probeResult{metrics: m, err: err, duration: time.Since(start)}
+	}
+
+	run(0)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				run(i)
+			}
+		}()
+	}
+	for i := 1; i < len(probes); i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// errorClass maps a probe error to a coarse class suitable as a metric label
+func errorClass(err error) string {
+	var apiErr *fortiHTTP.APIError
+	var netErr net.Error
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &apiErr):
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized, apiErr.StatusCode == http.StatusForbidden, apiErr.StatusCode == http.StatusNotFound:
+			return fmt.Sprintf("http_%d", apiErr.StatusCode)
+		case apiErr.StatusCode >= 500:
+			return "http_5xx"
+		default:
+			return "http_other"
+		}
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return "json_decode"
+	default:
+		return "other"
+	}
+}
 
-	return success, nil
+func (p *ProbeCollector) addMetrics(m ...prometheus.Metric) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.metrics = append(p.metrics, m...)
 }
 
 func (p *ProbeCollector) Collect(c chan<- prometheus.Metric) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	// Collect result of new probe functions
 	for _, m := range p.metrics {
 		c <- m
@@ -31,18 +31,27 @@
 package probe
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
 
 	"github.com/google/go-jsonnet"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 type preparedResp struct {
-	d []byte
-	q url.Values
+	d   []byte
+	q   url.Values
+	err error
 }
 
 type fakeClient struct {
@@ -65,6 +74,18 @@ func (c *fakeClient) prepare(path string, jfile string) {
 	})
 }
 
+// prepareError makes requests for path fail with err
+func (c *fakeClient) prepareError(path string, err error) {
+	u, err2 := url.Parse(path)
+	if err2 != nil {
+		panic(err2)
+	}
+	c.data[u.Path] = append(c.data[u.Path], preparedResp{
+		q:   u.Query(),
+		err: err,
+	})
+}
+
 func (c *fakeClient) Get(path string, query string, obj interface{}) error {
 	rs, ok := c.data[path]
 	if !ok {
@@ -81,6 +102,12 @@ alt:
 				continue alt
 			}
 		}
+		if r.err != nil {
+			return r.err
+		}
+		if sd, ok := obj.(http.StreamDecoder); ok {
+			return sd.DecodeStream(json.NewDecoder(bytes.NewReader(r.d)))
+		}
 		return json.Unmarshal(r.d, obj)
 	}
 	log.Fatalf("No prepared response matched URL %q, query %q", path, query)
@@ -113,8 +140,9 @@ func testProbe(pf probeFunc, c http.FortiHTTP, r Registry) bool {
 }
 
 func testProbeWithMetadata(pf probeFunc, c http.FortiHTTP, meta *TargetMetadata, r Registry) bool {
-	m, ok := pf(c, meta)
-	if !ok {
+	m, err := pf(c, meta)
+	if err != nil {
+		log.Printf("Probe failed: %v", err)
 		return false
 	}
 	p := &testProbeCollector{metrics: m}
@@ -125,3 +153,117 @@ func testProbeWithMetadata(pf probeFunc, c http.FortiHTTP, meta *TargetMetadata,
 func newFakeClient() *fakeClient {
 	return &fakeClient{data: map[string][]preparedResp{}}
 }
+
+func TestRunProbes(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int32
+	var firstDone atomic.Bool
+
+	mkProbe := func(name string, ok bool) probeDetailedFunc {
+		return probeDetailedFunc{name, func(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			mu.Lock()
+			if n > maxInFlight {
+				maxInFlight = n
+			}
+			mu.Unlock()
+			if name != "first" && !firstDone.Load() {
+				t.Errorf("probe %q started before the first probe finished", name)
+			}
+			time.Sleep(10 * time.Millisecond)
+			if name == "first" {
+				firstDone.Store(true)
+			}
+			d := prometheus.NewDesc("test_probe", "Test probe", []string{"name"}, nil)
+			var err error
+			if !ok {
+				err = fmt.Errorf("probe %s failed", name)
+			}
+			return []prometheus.Metric{prometheus.MustNewConstMetric(d, prometheus.GaugeValue, 1, name)}, err
+		}}
+	}
+
+	probes := []probeDetailedFunc{mkProbe("first", true)}
+	for _, n := range []string{"a", "b", "c", "d", "e", "f"} {
+		probes = append(probes, mkProbe(n, n != "c"))
+	}
+
+	results := runProbes(newFakeClient(), &TargetMetadata{}, probes, 2)
+	if len(results) != len(probes) {
+		t.Fatalf("runProbes() returned %d results, expected %d", len(results), len(probes))
+	}
+	for i, r := range results {
+		if len(r.metrics) != 1 || r.metrics[0].Desc() == nil {
+			t.Fatalf("result %d has unexpected metrics %v", i, r.metrics)
+		}
+		if want := probes[i].name != "c"; (r.err == nil) != want {
+			t.Errorf("result %d (%s) err = %v, expected success %v", i, probes[i].name, r.err, want)
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("runProbes() ran %d probes in parallel, expected at most 2", maxInFlight)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	var syntaxErr error
+	if err := json.Unmarshal([]byte("{"), &struct{}{}); err != nil {
+		syntaxErr = err
+	}
+	for _, tc := range []struct {
+		err  error
+		want string
+	}{
+		{context.DeadlineExceeded, "timeout"},
+		{&url.Error{Op: "Get", URL: "https://localhost", Err: context.DeadlineExceeded}, "timeout"},
+		{&http.APIError{StatusCode: 401}, "http_401"},
+		{&http.APIError{StatusCode: 403}, "http_403"},
+		{&http.APIError{StatusCode: 404}, "http_404"},
+		{&http.APIError{StatusCode: 502}, "http_5xx"},
+		{&http.APIError{StatusCode: 424}, "http_other"},
+		{syntaxErr, "json_decode"},
+		{fmt.Errorf("wrapped: %w", &json.UnmarshalTypeError{Value: "string"}), "json_decode"},
+		{fmt.Errorf("something else"), "other"},
+	} {
+		if got := errorClass(tc.err); got != tc.want {
+			t.Errorf("errorClass(%v) = %q, expected %q", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestProbeFailures(t *testing.T) {
+	forbidden := probeFailures.WithLabelValues("https://fw-failures", "System/Status", "http_403")
+	notFound := probeFailures.WithLabelValues("https://fw-failures", "System/Status", "http_404")
+	forbiddenBefore, notFoundBefore := testutil.ToFloat64(forbidden), testutil.ToFloat64(notFound)
+
+	p := &ProbeCollector{target: "https://fw-failures"}
+	probes := []probeDetailedFunc{{"System/Status", probeSystemStatus}}
+	results := []probeResult{{err: &http.APIError{StatusCode: 403}}}
+	if p.addResults(probes, results) {
+		t.Errorf("addResults() returned success for a failed probe")
+	}
+	if v := testutil.ToFloat64(forbidden) - forbiddenBefore; v != 1 {
+		t.Errorf("probeFailures for the target increased by %v, expected 1", v)
+	}
+
+	results = []probeResult{{err: &http.APIError{StatusCode: 404}}}
+	if !p.addResults(probes, results) {
+		t.Errorf("addResults() returned non-success for a missing endpoint")
+	}
+	if v := testutil.ToFloat64(notFound) - notFoundBefore; v != 1 {
+		t.Errorf("probeFailures for the missing endpoint increased by %v, expected 1", v)
+	}
+}
+
+func TestForgetTarget(t *testing.T) {
+	p := &ProbeCollector{target: "https://fw-forgotten"}
+	probes := []probeDetailedFunc{{"System/Status", probeSystemStatus}}
+	p.addResults(probes, []probeResult{{err: &http.APIError{StatusCode: 403}}})
+	before := testutil.CollectAndCount(probeFailures)
+
+	ForgetTarget("https://fw-forgotten")
+	if n := testutil.CollectAndCount(probeFailures); n != before-1 {
+		t.Errorf("ForgetTarget() left %d of %d series, expected %d", n, before, before-1)
+	}
+}
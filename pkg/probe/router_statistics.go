@@ -0,0 +1,82 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type RouterStatistics struct {
+	TotalLinesIPv4 float64 `json:"total_lines_ipv4"`
+	TotalLinesIPv6 float64 `json:"total_lines_ipv6"`
+}
+
+type RouterStatisticsResponse struct {
+	Results RouterStatistics `json:"results"`
+	VDOM    string           `json:"vdom"`
+}
+
+// routeTypes maps the route type filter of the statistics API to the type label
+var routeTypes = []struct {
+	filter string
+	label  string
+}{
+	{"connect", "connected"},
+	{"static", "static"},
+	{"bgp", "bgp"},
+	{"ospf", "ospf"},
+}
+
+func probeRouterStatistics(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	var (
+		mRoutes = prometheus.NewDesc(
+			"fortigate_router_routes",
+			"Number of routes in the routing table",
+			[]string{"vdom", "protocol"}, nil,
+		)
+		mRoutesByType = prometheus.NewDesc(
+			"fortigate_router_routes_by_type",
+			"Number of routes in the routing table, per route type",
+			[]string{"vdom", "protocol", "type"}, nil,
+		)
+	)
+
+	var rs []RouterStatisticsResponse
+	if err := c.Get("api/v2/monitor/router/statistics/select", "vdom=*", &rs); err != nil {
+		return nil, err
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range rs {
+		m = append(m, prometheus.MustNewConstMetric(mRoutes, prometheus.GaugeValue, r.Results.TotalLinesIPv4, r.VDOM, "ipv4"))
+		m = append(m, prometheus.MustNewConstMetric(mRoutes, prometheus.GaugeValue, r.Results.TotalLinesIPv6, r.VDOM, "ipv6"))
+	}
+
+	for _, t := range routeTypes {
+		var rts []RouterStatisticsResponse
+		if err := c.Get("api/v2/monitor/router/statistics/select", "vdom=*&type="+t.filter, &rts); err != nil {
+			log.Printf("Warning: failed to fetch %s route statistics, keeping the totals: %v", t.label, err)
+			continue
+		}
+		for _, r := range rts {
+			m = append(m, prometheus.MustNewConstMetric(mRoutesByType, prometheus.GaugeValue, r.Results.TotalLinesIPv4, r.VDOM, "ipv4", t.label))
+			m = append(m, prometheus.MustNewConstMetric(mRoutesByType, prometheus.GaugeValue, r.Results.TotalLinesIPv6, r.VDOM, "ipv6", t.label))
+		}
+	}
+
+	return m, nil
+}
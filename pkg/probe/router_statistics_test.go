@@ -0,0 +1,87 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRouterStatistics(t *testing.T) {
+	c := newFakeClient()
+	for _, rt := range []string{"connect", "static", "bgp", "ospf"} {
+		c.prepare("api/v2/monitor/router/statistics/select?type="+rt, "testdata/router-"+rt+".jsonnet")
+	}
+	c.prepare("api/v2/monitor/router/statistics/select", "testdata/router.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeRouterStatistics, c, r) {
+		t.Errorf("probeRouterStatistics() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_router_routes Number of routes in the routing table
+	# TYPE fortigate_router_routes gauge
+	fortigate_router_routes{protocol="ipv4",vdom="FG-traffic"} 0
+	fortigate_router_routes{protocol="ipv4",vdom="root"} 2
+	fortigate_router_routes{protocol="ipv6",vdom="FG-traffic"} 1
+	fortigate_router_routes{protocol="ipv6",vdom="root"} 3
+	# HELP fortigate_router_routes_by_type Number of routes in the routing table, per route type
+	# TYPE fortigate_router_routes_by_type gauge
+	fortigate_router_routes_by_type{protocol="ipv4",type="bgp",vdom="FG-traffic"} 0
+	fortigate_router_routes_by_type{protocol="ipv4",type="bgp",vdom="root"} 0
+	fortigate_router_routes_by_type{protocol="ipv4",type="connected",vdom="FG-traffic"} 0
+	fortigate_router_routes_by_type{protocol="ipv4",type="connected",vdom="root"} 1
+	fortigate_router_routes_by_type{protocol="ipv4",type="ospf",vdom="FG-traffic"} 0
+	fortigate_router_routes_by_type{protocol="ipv4",type="ospf",vdom="root"} 0
+	fortigate_router_routes_by_type{protocol="ipv4",type="static",vdom="FG-traffic"} 0
+	fortigate_router_routes_by_type{protocol="ipv4",type="static",vdom="root"} 1
+	fortigate_router_routes_by_type{protocol="ipv6",type="bgp",vdom="FG-traffic"} 0
+	fortigate_router_routes_by_type{protocol="ipv6",type="bgp",vdom="root"} 0
+	fortigate_router_routes_by_type{protocol="ipv6",type="connected",vdom="FG-traffic"} 1
+	fortigate_router_routes_by_type{protocol="ipv6",type="connected",vdom="root"} 2
+	fortigate_router_routes_by_type{protocol="ipv6",type="ospf",vdom="FG-traffic"} 0
+	fortigate_router_routes_by_type{protocol="ipv6",type="ospf",vdom="root"} 0
+	fortigate_router_routes_by_type{protocol="ipv6",type="static",vdom="FG-traffic"} 0
+	fortigate_router_routes_by_type{protocol="ipv6",type="static",vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestRouterStatisticsWithoutType(t *testing.T) {
+	c := newFakeClient()
+	c.prepareError("api/v2/monitor/router/statistics/select?type=bgp", &http.APIError{StatusCode: 500})
+	for _, rt := range []string{"connect", "static", "ospf"} {
+		c.prepare("api/v2/monitor/router/statistics/select?type="+rt, "testdata/router-"+rt+".jsonnet")
+	}
+	c.prepare("api/v2/monitor/router/statistics/select", "testdata/router.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeRouterStatistics, c, r) {
+		t.Errorf("probeRouterStatistics() returned non-success")
+	}
+
+	if n, err := testutil.GatherAndCount(r, "fortigate_router_routes"); err != nil || n != 4 {
+		t.Errorf("expected 4 route totals, got %d (err %v)", n, err)
+	}
+	// connected, static and ospf for two VDOMs and protocols
+	if n, err := testutil.GatherAndCount(r, "fortigate_router_routes_by_type"); err != nil || n != 12 {
+		t.Errorf("expected 12 routes by type, got %d (err %v)", n, err)
+	}
+}
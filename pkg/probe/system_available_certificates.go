@@ -14,13 +14,11 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeSystemAvailableCertificates(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeSystemAvailableCertificates(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		certificateInfo = prometheus.NewDesc(
 			"fortigate_certificate_info",
@@ -63,16 +61,14 @@ func probeSystemAvailableCertificates(c http.FortiHTTP, meta *TargetMetadata) ([
 
 	var globalResponse Response
 	if err := c.Get("api/v2/monitor/system/available-certificates", "scope=global", &globalResponse); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 	globalResponse.Scope = "global"
 
 	var vdomResponses []Response
 
 	if err := c.Get("api/v2/monitor/system/available-certificates", "vdom=*", &vdomResponses); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 	for i := range vdomResponses {
 		vdomResponses[i].Scope = "vdom"
@@ -93,5 +89,5 @@ func probeSystemAvailableCertificates(c http.FortiHTTP, meta *TargetMetadata) ([
 			m = append(m, prometheus.MustNewConstMetric(certificateCMDBReferences, prometheus.GaugeValue, result.QRef, result.Name, result.Source, response.Scope, response.VDOM))
 		}
 	}
-	return m, true
+	return m, nil
 }
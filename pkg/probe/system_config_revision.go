@@ -0,0 +1,87 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strconv"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func probeSystemConfigRevision(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	var (
+		mRevisions = prometheus.NewDesc(
+			"fortigate_config_revisions",
+			"Number of stored configuration revisions",
+			nil, nil,
+		)
+		mLastRevisionTime = prometheus.NewDesc(
+			"fortigate_config_revision_last_timestamp_seconds",
+			"Epoch time in seconds of the latest configuration revision",
+			nil, nil,
+		)
+		mLastRevisionInfo = prometheus.NewDesc(
+			"fortigate_config_revision_info",
+			"Information about the latest configuration revision",
+			[]string{"id", "admin", "comment"}, nil,
+		)
+		mUnsaved = prometheus.NewDesc(
+			"fortigate_config_unsaved_changes",
+			"Whether the running configuration has changes that are not saved as a revision",
+			nil, nil,
+		)
+	)
+
+	type revision struct {
+		ID      int64
+		Time    float64
+		Admin   string
+		Comment string
+	}
+	type configRevisions struct {
+		Revisions            []revision
+		CurrentConfigUnsaved bool `json:"current_config_unsaved"`
+	}
+	type configRevisionResponse struct {
+		Results configRevisions
+	}
+	var r configRevisionResponse
+
+	if err := c.Get("api/v2/monitor/system/config-revision", "", &r); err != nil {
+		return nil, err
+	}
+
+	unsaved := 0.0
+	if r.Results.CurrentConfigUnsaved {
+		unsaved = 1.0
+	}
+	m := []prometheus.Metric{
+		prometheus.MustNewConstMetric(mRevisions, prometheus.GaugeValue, float64(len(r.Results.Revisions))),
+		prometheus.MustNewConstMetric(mUnsaved, prometheus.GaugeValue, unsaved),
+	}
+
+	if len(r.Results.Revisions) == 0 {
+		return m, nil
+	}
+	latest := r.Results.Revisions[0]
+	for _, rev := range r.Results.Revisions[1:] {
+		if rev.Time > latest.Time {
+			latest = rev
+		}
+	}
+	m = append(m, prometheus.MustNewConstMetric(mLastRevisionTime, prometheus.GaugeValue, latest.Time))
+	m = append(m, prometheus.MustNewConstMetric(mLastRevisionInfo, prometheus.GaugeValue, 1, strconv.FormatInt(latest.ID, 10), latest.Admin, latest.Comment))
+	return m, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemConfigRevision(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/config-revision", "testdata/config-revision.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemConfigRevision, c, r) {
+		t.Errorf("probeSystemConfigRevision() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_config_revision_info Information about the latest configuration revision
+	# TYPE fortigate_config_revision_info gauge
+	fortigate_config_revision_info{admin="bluecmd",comment="Test SNMP",id="2"} 1
+	# HELP fortigate_config_revision_last_timestamp_seconds Epoch time in seconds of the latest configuration revision
+	# TYPE fortigate_config_revision_last_timestamp_seconds gauge
+	fortigate_config_revision_last_timestamp_seconds 1.590345408e+09
+	# HELP fortigate_config_revisions Number of stored configuration revisions
+	# TYPE fortigate_config_revisions gauge
+	fortigate_config_revisions 2
+	# HELP fortigate_config_unsaved_changes Whether the running configuration has changes that are not saved as a revision
+	# TYPE fortigate_config_unsaved_changes gauge
+	fortigate_config_unsaved_changes 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
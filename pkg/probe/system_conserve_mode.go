@@ -0,0 +1,159 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// conserveModeStale is how long a unit that is no longer scraped is remembered
+const conserveModeStale = time.Hour
+
+type conserveModeEntry struct {
+	since    time.Time
+	lastSeen time.Time
+}
+
+var (
+	// conserveModeSince tracks when the exporter first saw a unit, by serial,
+	// in conserve mode as FortiOS does not report when conserve mode was entered
+	conserveModeSinceMu sync.Mutex
+	conserveModeSince   = map[string]*conserveModeEntry{}
+)
+
+// conserveModeStart returns when the unit was first seen in conserve mode, or
+// now if it is not in conserve mode. Units not seen for conserveModeStale are forgotten.
+func conserveModeStart(serial string, conserve bool, now time.Time) time.Time {
+	conserveModeSinceMu.Lock()
+	defer conserveModeSinceMu.Unlock()
+
+	for s, e := range conserveModeSince {
+		if now.Sub(e.lastSeen) > conserveModeStale {
+			delete(conserveModeSince, s)
+		}
+	}
+	if !conserve {
+		delete(conserveModeSince, serial)
+		return now
+	}
+	e, ok := conserveModeSince[serial]
+	if !ok {
+		e = &conserveModeEntry{since: now}
+		conserveModeSince[serial] = e
+	}
+	e.lastSeen = now
+	return e.since
+}
+
+func probeSystemConserveMode(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	if meta.VersionMajor < 6 || (meta.VersionMajor == 6 && meta.VersionMinor < 4) {
+		// not supported version. Before 6.4.0 the memory thresholds are not configurable
+		return nil, nil
+	}
+
+	var (
+		mConserveMode = prometheus.NewDesc(
+			"fortigate_memory_conserve_mode",
+			"Whether the unit is in memory conserve mode (0 - No, 1 - Yes)",
+			nil, nil,
+		)
+		mConserveLevel = prometheus.NewDesc(
+			"fortigate_memory_conserve_mode_level",
+			"Current memory conserve mode level, estimated from the memory usage and the configured thresholds as FortiOS only reports whether conserve mode is active",
+			[]string{"level"}, nil,
+		)
+		mConserveSeconds = prometheus.NewDesc(
+			"fortigate_memory_conserve_mode_seconds",
+			"Seconds the unit has been in memory conserve mode as observed by the exporter, 0 if not in conserve mode",
+			nil, nil,
+		)
+		mThreshold = prometheus.NewDesc(
+			"fortigate_memory_threshold_ratio",
+			"Configured memory usage ratio thresholds for entering (red, extreme) and leaving (green) conserve mode",
+			[]string{"threshold"}, nil,
+		)
+	)
+
+	type webUIState struct {
+		Results struct {
+			ConserveMode bool `json:"conserve_mode"`
+		}
+		Serial string
+	}
+	var state webUIState
+	if err := c.Get("api/v2/monitor/web-ui/state", "", &state); err != nil {
+		return nil, err
+	}
+
+	type systemGlobal struct {
+		Results struct {
+			ThresholdExtreme float64 `json:"memory-use-threshold-extreme"`
+			ThresholdRed     float64 `json:"memory-use-threshold-red"`
+			ThresholdGreen   float64 `json:"memory-use-threshold-green"`
+		}
+	}
+	var global systemGlobal
+	if err := c.Get("api/v2/cmdb/system/global", "", &global); err != nil {
+		return nil, err
+	}
+
+	type resUsage struct {
+		Current float64
+	}
+	type systemResourceUsage struct {
+		Results struct {
+			Mem []resUsage
+		}
+	}
+	var sr systemResourceUsage
+	if err := c.Get("api/v2/monitor/system/resource/usage", "interval=1-min&scope=global", &sr); err != nil {
+		return nil, err
+	}
+
+	level := "green"
+	if state.Results.ConserveMode {
+		level = "red"
+		if len(sr.Results.Mem) > 0 && sr.Results.Mem[0].Current >= global.Results.ThresholdExtreme {
+			level = "extreme"
+		}
+	}
+
+	now := time.Now()
+	since := conserveModeStart(state.Serial, state.Results.ConserveMode, now)
+
+	conserve := 0.0
+	if state.Results.ConserveMode {
+		conserve = 1.0
+	}
+
+	m := []prometheus.Metric{
+		prometheus.MustNewConstMetric(mConserveMode, prometheus.GaugeValue, conserve),
+		prometheus.MustNewConstMetric(mConserveSeconds, prometheus.GaugeValue, now.Sub(since).Seconds()),
+		prometheus.MustNewConstMetric(mThreshold, prometheus.GaugeValue, global.Results.ThresholdGreen/100, "green"),
+		prometheus.MustNewConstMetric(mThreshold, prometheus.GaugeValue, global.Results.ThresholdRed/100, "red"),
+		prometheus.MustNewConstMetric(mThreshold, prometheus.GaugeValue, global.Results.ThresholdExtreme/100, "extreme"),
+	}
+	for _, l := range []string{"green", "red", "extreme"} {
+		v := 0.0
+		if l == level {
+			v = 1.0
+		}
+		m = append(m, prometheus.MustNewConstMetric(mConserveLevel, prometheus.GaugeValue, v, l))
+	}
+	return m, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemConserveMode(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/web-ui/state", "testdata/web-ui-state.jsonnet")
+	c.prepare("api/v2/cmdb/system/global", "testdata/system-global.jsonnet")
+	c.prepare("api/v2/monitor/system/resource/usage", "testdata/usage.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemConserveMode, c, r) {
+		t.Errorf("probeSystemConserveMode() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_memory_conserve_mode Whether the unit is in memory conserve mode (0 - No, 1 - Yes)
+	# TYPE fortigate_memory_conserve_mode gauge
+	fortigate_memory_conserve_mode 0
+	# HELP fortigate_memory_conserve_mode_level Current memory conserve mode level, estimated from the memory usage and the configured thresholds as FortiOS only reports whether conserve mode is active
+	# TYPE fortigate_memory_conserve_mode_level gauge
+	fortigate_memory_conserve_mode_level{level="extreme"} 0
+	fortigate_memory_conserve_mode_level{level="green"} 1
+	fortigate_memory_conserve_mode_level{level="red"} 0
+	# HELP fortigate_memory_conserve_mode_seconds Seconds the unit has been in memory conserve mode as observed by the exporter, 0 if not in conserve mode
+	# TYPE fortigate_memory_conserve_mode_seconds gauge
+	fortigate_memory_conserve_mode_seconds 0
+	# HELP fortigate_memory_threshold_ratio Configured memory usage ratio thresholds for entering (red, extreme) and leaving (green) conserve mode
+	# TYPE fortigate_memory_threshold_ratio gauge
+	fortigate_memory_threshold_ratio{threshold="extreme"} 0.95
+	fortigate_memory_threshold_ratio{threshold="green"} 0.82
+	fortigate_memory_threshold_ratio{threshold="red"} 0.88
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSystemConserveModeExtreme(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/web-ui/state", "testdata/web-ui-state-conserve.jsonnet")
+	c.prepare("api/v2/cmdb/system/global", "testdata/system-global.jsonnet")
+	c.prepare("api/v2/monitor/system/resource/usage", "testdata/usage-conserve.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemConserveMode, c, r) {
+		t.Errorf("probeSystemConserveMode() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_memory_conserve_mode Whether the unit is in memory conserve mode (0 - No, 1 - Yes)
+	# TYPE fortigate_memory_conserve_mode gauge
+	fortigate_memory_conserve_mode 1
+	# HELP fortigate_memory_conserve_mode_level Current memory conserve mode level, estimated from the memory usage and the configured thresholds as FortiOS only reports whether conserve mode is active
+	# TYPE fortigate_memory_conserve_mode_level gauge
+	fortigate_memory_conserve_mode_level{level="extreme"} 1
+	fortigate_memory_conserve_mode_level{level="green"} 0
+	fortigate_memory_conserve_mode_level{level="red"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_memory_conserve_mode", "fortigate_memory_conserve_mode_level"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+
+	conserveModeSinceMu.Lock()
+	_, tracked := conserveModeSince["FGT61FT000000000"]
+	conserveModeSinceMu.Unlock()
+	if !tracked {
+		t.Errorf("conserve mode start was not tracked")
+	}
+}
+
+func TestSystemConserveModeUnsupportedVersion(t *testing.T) {
+	c := newFakeClient()
+	meta := &TargetMetadata{
+		VersionMajor: 6,
+		VersionMinor: 2,
+	}
+	r := prometheus.NewPedanticRegistry()
+	if !testProbeWithMetadata(probeSystemConserveMode, c, meta, r) {
+		t.Errorf("probeSystemConserveMode() returned non-success")
+	}
+	if n, err := testutil.GatherAndCount(r); err != nil || n != 0 {
+		t.Errorf("probeSystemConserveMode() on 6.2 returned %d metrics (%v), expected none", n, err)
+	}
+}
+
+func TestConserveModeStartForgetsStaleUnits(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	conserveModeStart("FGTSTALE00000000", true, now)
+	if since := conserveModeStart("FGTSTALE00000000", true, now.Add(time.Minute)); !since.Equal(now) {
+		t.Errorf("conserveModeStart() = %v, expected %v", since, now)
+	}
+
+	conserveModeStart("FGTOTHER00000000", false, now.Add(2*time.Hour))
+	conserveModeSinceMu.Lock()
+	_, tracked := conserveModeSince["FGTSTALE00000000"]
+	conserveModeSinceMu.Unlock()
+	if tracked {
+		t.Errorf("unit that is no longer scraped is still tracked")
+	}
+}
@@ -14,8 +14,6 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -31,7 +29,7 @@ type SystemFortimanagerStatus struct {
 	VDOM    string                    `json:"vdom"`
 }
 
-func probeSystemFortimanagerStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeSystemFortimanagerStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		FortimanStat_id = prometheus.NewDesc(
 			"fortigate_fortimanager_connection_status",
@@ -47,8 +45,7 @@ func probeSystemFortimanagerStatus(c http.FortiHTTP, meta *TargetMetadata) ([]pr
 
 	var res []SystemFortimanagerStatus
 	if err := c.Get("api/v2/monitor/system/fortimanager/status", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -92,5 +89,5 @@ func probeSystemFortimanagerStatus(c http.FortiHTTP, meta *TargetMetadata) ([]pr
 		m = append(m, prometheus.MustNewConstMetric(FortimanReg_id, prometheus.GaugeValue, RegistrationUnregistered, r.VDOM, r.Results.Mode, "unregistered"))
 	}
 
-	return m, true
+	return m, nil
 }
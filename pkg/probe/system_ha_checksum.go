@@ -14,35 +14,38 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type HAChecksumResults struct {
-	IsManageMaster int    `json:"is_manage_master"`
-	IsRootMaster   int    `json:"is_root_master"`
-	SerialNo       string `json:"serial_no"`
+	IsManageMaster int               `json:"is_manage_master"`
+	IsRootMaster   int               `json:"is_root_master"`
+	Checksum       map[string]string `json:"checksum"`
+	SerialNo       string            `json:"serial_no"`
 }
 
 type HAChecksum struct {
 	Results []HAChecksumResults `json:"results"`
 }
 
-func probeSystemHAChecksum(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeSystemHAChecksum(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		IsMaster = prometheus.NewDesc(
 			"fortigate_ha_member_has_role",
 			"Master/Slave information",
 			[]string{"role", "serial"}, nil,
 		)
+		InSync = prometheus.NewDesc(
+			"fortigate_ha_member_config_in_sync",
+			"Whether the configuration checksum of a member matches the primary, per section (global, all or a VDOM)",
+			[]string{"serial", "section"}, nil,
+		)
 	)
 
 	var res HAChecksum
 	if err := c.Get("api/v2/monitor/system/ha-checksums", "scope=global", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -51,5 +54,34 @@ func probeSystemHAChecksum(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 		m = append(m, prometheus.MustNewConstMetric(IsMaster, prometheus.GaugeValue, float64(response.IsRootMaster), "root_master", response.SerialNo))
 	}
 
-	return m, true
+	var primary *HAChecksumResults
+	for i, response := range res.Results {
+		if response.IsManageMaster == 1 {
+			primary = &res.Results[i]
+			break
+		}
+	}
+	if primary == nil {
+		return m, nil
+	}
+	for _, response := range res.Results {
+		// A section only known to one side is out of sync as well
+		sections := map[string]bool{}
+		for section := range primary.Checksum {
+			sections[section] = true
+		}
+		for section := range response.Checksum {
+			sections[section] = true
+		}
+		for section := range sections {
+			sum, ok := response.Checksum[section]
+			inSync := 0.0
+			if ok && sum == primary.Checksum[section] {
+				inSync = 1.0
+			}
+			m = append(m, prometheus.MustNewConstMetric(InSync, prometheus.GaugeValue, inSync, response.SerialNo, section))
+		}
+	}
+
+	return m, nil
 }
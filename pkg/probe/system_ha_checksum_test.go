@@ -36,9 +36,41 @@ func TestHAChecksum(t *testing.T) {
 	fortigate_ha_member_has_role{role="manage_master", serial="SERIAL222222222"} 0
 	fortigate_ha_member_has_role{role="root_master", serial="SERIAL111111111"} 1
 	fortigate_ha_member_has_role{role="root_master", serial="SERIAL222222222"} 0
+	# HELP fortigate_ha_member_config_in_sync Whether the configuration checksum of a member matches the primary, per section (global, all or a VDOM)
+	# TYPE fortigate_ha_member_config_in_sync gauge
+	fortigate_ha_member_config_in_sync{section="all", serial="SERIAL111111111"} 1
+	fortigate_ha_member_config_in_sync{section="all", serial="SERIAL222222222"} 1
+	fortigate_ha_member_config_in_sync{section="global", serial="SERIAL111111111"} 1
+	fortigate_ha_member_config_in_sync{section="global", serial="SERIAL222222222"} 1
+	fortigate_ha_member_config_in_sync{section="root", serial="SERIAL111111111"} 1
+	fortigate_ha_member_config_in_sync{section="root", serial="SERIAL222222222"} 1
 	`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func TestHAChecksumDrift(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/ha-checksums", "testdata/ha-checksum-drift.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemHAChecksum, c, r) {
+		t.Errorf("probeSystemHAChecksum() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_ha_member_config_in_sync Whether the configuration checksum of a member matches the primary, per section (global, all or a VDOM)
+	# TYPE fortigate_ha_member_config_in_sync gauge
+	fortigate_ha_member_config_in_sync{section="all", serial="SERIAL111111111"} 1
+	fortigate_ha_member_config_in_sync{section="all", serial="SERIAL222222222"} 0
+	fortigate_ha_member_config_in_sync{section="global", serial="SERIAL111111111"} 1
+	fortigate_ha_member_config_in_sync{section="global", serial="SERIAL222222222"} 1
+	fortigate_ha_member_config_in_sync{section="root", serial="SERIAL111111111"} 1
+	fortigate_ha_member_config_in_sync{section="root", serial="SERIAL222222222"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_ha_member_config_in_sync"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func probeSystemHAHistory(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	var (
+		mLastFailover = prometheus.NewDesc(
+			"fortigate_ha_last_failover_timestamp_seconds",
+			"Time of the last primary selection in the HA cluster",
+			nil, nil,
+		)
+		mFailoverInfo = prometheus.NewDesc(
+			"fortigate_ha_last_failover_info",
+			"Info metric regarding the last primary selection in the HA cluster",
+			[]string{"serial", "reason"}, nil,
+		)
+		mRoleChanges = prometheus.NewDesc(
+			"fortigate_ha_role_changes",
+			"Number of primary selections in the HA history kept by FortiOS",
+			nil, nil,
+		)
+		mHeartbeatUp = prometheus.NewDesc(
+			"fortigate_ha_heartbeat_interface_up",
+			"Whether the HA heartbeat interface has link",
+			[]string{"interface"}, nil,
+		)
+		mHeartbeatPriority = prometheus.NewDesc(
+			"fortigate_ha_heartbeat_interface_priority",
+			"Priority of the HA heartbeat interface",
+			[]string{"interface"}, nil,
+		)
+	)
+
+	type haHistoryEntry struct {
+		Time     float64 `json:"time"`
+		SerialNo string  `json:"serial_no"`
+		Message  string  `json:"message"`
+	}
+	type haHistory struct {
+		Results []haHistoryEntry
+	}
+	var rh haHistory
+
+	if err := c.Get("api/v2/monitor/system/ha-history", "", &rh); err != nil {
+		return nil, err
+	}
+
+	// The history also records other events such as heartbeat link changes
+	var selections []haHistoryEntry
+	for _, e := range rh.Results {
+		if strings.Contains(e.Message, " is selected as the primary") {
+			selections = append(selections, e)
+		}
+	}
+
+	m := []prometheus.Metric{
+		prometheus.MustNewConstMetric(mRoleChanges, prometheus.GaugeValue, float64(len(selections))),
+	}
+
+	if len(selections) > 0 {
+		last := selections[0]
+		for _, e := range selections[1:] {
+			if e.Time > last.Time {
+				last = e
+			}
+		}
+		m = append(m, prometheus.MustNewConstMetric(mLastFailover, prometheus.GaugeValue, last.Time))
+		m = append(m, prometheus.MustNewConstMetric(mFailoverInfo, prometheus.GaugeValue, 1, last.SerialNo, haFailoverReason(last.Message)))
+	}
+
+	type haConfig struct {
+		Results struct {
+			HBDev string `json:"hbdev"`
+		} `json:"results"`
+	}
+	var rc haConfig
+
+	if err := c.Get("api/v2/cmdb/system/ha", "", &rc); err != nil {
+		log.Printf("Warning: failed to fetch HA configuration, heartbeat interface metrics will be missing: %v", err)
+		return m, nil
+	}
+
+	hbdevs := parseHBDev(rc.Results.HBDev)
+	if len(hbdevs) == 0 {
+		return m, nil
+	}
+
+	type ifResult struct {
+		Name string
+		Link bool
+	}
+	type ifResponse struct {
+		Results map[string]ifResult
+	}
+	var r []ifResponse
+
+	if err := c.Get("api/v2/monitor/system/interface/select", "vdom=*&include_vlan=true&include_aggregate=true", &r); err != nil {
+		log.Printf("Warning: failed to fetch interface status, heartbeat interface metrics will be missing: %v", err)
+		return m, nil
+	}
+	link := map[string]bool{}
+	for _, v := range r {
+		for _, ir := range v.Results {
+			link[ir.Name] = ir.Link
+		}
+	}
+
+	for _, hb := range hbdevs {
+		hasLink, ok := link[hb.name]
+		if !ok {
+			continue
+		}
+		up := 0.0
+		if hasLink {
+			up = 1.0
+		}
+		m = append(m, prometheus.MustNewConstMetric(mHeartbeatUp, prometheus.GaugeValue, up, hb.name))
+		m = append(m, prometheus.MustNewConstMetric(mHeartbeatPriority, prometheus.GaugeValue, hb.priority, hb.name))
+	}
+	return m, nil
+}
+
+type hbDev struct {
+	name     string
+	priority float64
+}
+
+// parseHBDev parses the heartbeat interface list of the HA config, which is
+// given as quoted interface names followed by their priority, e.g. "port9" 50 "port10" 0
+func parseHBDev(s string) []hbDev {
+	f := strings.Fields(s)
+	devs := []hbDev{}
+	for i := 0; i+1 < len(f); i += 2 {
+		prio, err := strconv.ParseFloat(f[i+1], 64)
+		if err != nil {
+			continue
+		}
+		devs = append(devs, hbDev{strings.Trim(f[i], `"`), prio})
+	}
+	return devs
+}
+
+// haFailoverReason returns the reason of a primary selection message such as
+// "FG100F0000000001 is selected as the primary because it has the largest value of uptime."
+func haFailoverReason(msg string) string {
+	if _, reason, ok := strings.Cut(msg, " because "); ok {
+		msg = reason
+	}
+	return strings.TrimSuffix(strings.TrimSpace(msg), ".")
+}
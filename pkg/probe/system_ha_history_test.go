@@ -0,0 +1,99 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestHAHistory(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/ha-history", "testdata/ha-history.jsonnet")
+	c.prepare("api/v2/cmdb/system/ha", "testdata/ha-config-hbdev.jsonnet")
+	c.prepare("api/v2/monitor/system/interface/select", "testdata/interface.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemHAHistory, c, r) {
+		t.Errorf("probeSystemHAHistory() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_ha_heartbeat_interface_priority Priority of the HA heartbeat interface
+	# TYPE fortigate_ha_heartbeat_interface_priority gauge
+	fortigate_ha_heartbeat_interface_priority{interface="internal1"} 50
+	fortigate_ha_heartbeat_interface_priority{interface="internal3"} 0
+	# HELP fortigate_ha_heartbeat_interface_up Whether the HA heartbeat interface has link
+	# TYPE fortigate_ha_heartbeat_interface_up gauge
+	fortigate_ha_heartbeat_interface_up{interface="internal1"} 1
+	fortigate_ha_heartbeat_interface_up{interface="internal3"} 0
+	# HELP fortigate_ha_last_failover_info Info metric regarding the last primary selection in the HA cluster
+	# TYPE fortigate_ha_last_failover_info gauge
+	fortigate_ha_last_failover_info{reason="it has the largest value of override priority",serial="SERIAL222222222"} 1
+	# HELP fortigate_ha_last_failover_timestamp_seconds Time of the last primary selection in the HA cluster
+	# TYPE fortigate_ha_last_failover_timestamp_seconds gauge
+	fortigate_ha_last_failover_timestamp_seconds 1.652170012e+09
+	# HELP fortigate_ha_role_changes Number of primary selections in the HA history kept by FortiOS
+	# TYPE fortigate_ha_role_changes gauge
+	fortigate_ha_role_changes 3
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestHAHistoryWithoutHeartbeat(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config bool
+	}{
+		{"config", false},
+		{"interfaces", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeClient()
+			c.prepare("api/v2/monitor/system/ha-history", "testdata/ha-history.jsonnet")
+			if tc.config {
+				c.prepare("api/v2/cmdb/system/ha", "testdata/ha-config-hbdev.jsonnet")
+			} else {
+				c.prepareError("api/v2/cmdb/system/ha", &http.APIError{StatusCode: 403})
+			}
+			c.prepareError("api/v2/monitor/system/interface/select", &http.APIError{StatusCode: 403})
+			r := prometheus.NewPedanticRegistry()
+			if !testProbe(probeSystemHAHistory, c, r) {
+				t.Errorf("probeSystemHAHistory() returned non-success")
+			}
+
+			em := `
+			# HELP fortigate_ha_last_failover_info Info metric regarding the last primary selection in the HA cluster
+			# TYPE fortigate_ha_last_failover_info gauge
+			fortigate_ha_last_failover_info{reason="it has the largest value of override priority",serial="SERIAL222222222"} 1
+			# HELP fortigate_ha_last_failover_timestamp_seconds Time of the last primary selection in the HA cluster
+			# TYPE fortigate_ha_last_failover_timestamp_seconds gauge
+			fortigate_ha_last_failover_timestamp_seconds 1.652170012e+09
+			# HELP fortigate_ha_role_changes Number of primary selections in the HA history kept by FortiOS
+			# TYPE fortigate_ha_role_changes gauge
+			fortigate_ha_role_changes 3
+			`
+
+			if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+				t.Fatalf("metric compare: err %v", err)
+			}
+		})
+	}
+}
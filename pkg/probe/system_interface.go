@@ -14,13 +14,14 @@
 package probe
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeSystemInterface(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeSystemInterface(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		mLink = prometheus.NewDesc(
 			"fortigate_interface_link_up",
@@ -62,12 +63,30 @@ func probeSystemInterface(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.
 			"Number of reception errors detected on the interface",
 			[]string{"vdom", "name", "alias", "parent"}, nil,
 		)
+		mDuplex = prometheus.NewDesc(
+			"fortigate_interface_duplex",
+			"Duplex mode negotiated on the port (0 - Half, 1 - Full)",
+			[]string{"vdom", "name", "alias", "parent"}, nil,
+		)
+		mAdminUp = prometheus.NewDesc(
+			"fortigate_interface_admin_up",
+			"Whether the interface is administratively up or not",
+			[]string{"vdom", "name", "alias", "parent"}, nil,
+		)
+		mInfo = prometheus.NewDesc(
+			"fortigate_interface_info",
+			"Configuration and addressing of the interface",
+			[]string{"vdom", "name", "alias", "parent", "type", "role", "mac", "mtu", "address"}, nil,
+		)
 	)
 
 	type ifResult struct {
 		Id        string
 		Name      string
 		Alias     string
+		Mac       string
+		IP        string
+		Mask      int
 		Link      bool
 		Speed     float64
 		Duplex    float64
@@ -86,9 +105,31 @@ func probeSystemInterface(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.
 	var r []ifResponse
 
 	if err := c.Get("api/v2/monitor/system/interface/select", "vdom=*&include_vlan=true&include_aggregate=true", &r); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
+	}
+
+	type ifConfig struct {
+		Name   string
+		Status string
+		Type   string
+		Role   string
+		MTU    int
+	}
+	type ifConfigResponse struct {
+		Results []ifConfig
 	}
+	var rc ifConfigResponse
+
+	// Interfaces are global objects, so their configuration is not fetched per VDOM
+	if err := c.Get("api/v2/cmdb/system/interface", "format=name|vdom|status|type|role|mtu", &rc); err != nil {
+		// The monitor metrics do not depend on it, e.g. the token may lack cmdb read access
+		log.Printf("Warning: failed to fetch interface configuration, admin status and config info will be missing: %v", err)
+	}
+	ifc := map[string]*ifConfig{}
+	for i, cfg := range rc.Results {
+		ifc[cfg.Name] = &rc.Results[i]
+	}
+
 	m := []prometheus.Metric{}
 	for _, v := range r {
 		for _, ir := range v.Results {
@@ -104,7 +145,23 @@ func probeSystemInterface(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.
 			m = append(m, prometheus.MustNewConstMetric(mRxB, prometheus.CounterValue, ir.RxBytes, v.VDOM, ir.Name, ir.Alias, ir.Interface))
 			m = append(m, prometheus.MustNewConstMetric(mTxErr, prometheus.CounterValue, ir.TxErrors, v.VDOM, ir.Name, ir.Alias, ir.Interface))
 			m = append(m, prometheus.MustNewConstMetric(mRxErr, prometheus.CounterValue, ir.RxErrors, v.VDOM, ir.Name, ir.Alias, ir.Interface))
+			m = append(m, prometheus.MustNewConstMetric(mDuplex, prometheus.GaugeValue, ir.Duplex, v.VDOM, ir.Name, ir.Alias, ir.Interface))
+
+			address := ""
+			if ir.IP != "" && ir.IP != "0.0.0.0" {
+				address = fmt.Sprintf("%s/%d", ir.IP, ir.Mask)
+			}
+			ifType, role, mtu := "", "", ""
+			if cfg, ok := ifc[ir.Name]; ok {
+				ifType, role, mtu = cfg.Type, cfg.Role, fmt.Sprintf("%d", cfg.MTU)
+				adminf := 0.0
+				if cfg.Status == "up" {
+					adminf = 1.0
+				}
+				m = append(m, prometheus.MustNewConstMetric(mAdminUp, prometheus.GaugeValue, adminf, v.VDOM, ir.Name, ir.Alias, ir.Interface))
+			}
+			m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, 1, v.VDOM, ir.Name, ir.Alias, ir.Interface, ifType, role, ir.Mac, mtu, address))
 		}
 	}
-	return m, true
+	return m, nil
 }
@@ -0,0 +1,112 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func probeSystemInterfaceAggregate(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	var (
+		mMemberLinkUp = prometheus.NewDesc(
+			"fortigate_interface_aggregate_member_link_up",
+			"Whether the member interface of the aggregate has link, FortiOS does not expose the LACP state through the REST API",
+			[]string{"vdom", "aggregate", "member"}, nil,
+		)
+		mMembers = prometheus.NewDesc(
+			"fortigate_interface_aggregate_members",
+			"Number of configured member interfaces of an aggregate interface",
+			[]string{"vdom", "aggregate"}, nil,
+		)
+		mMembersLinkUp = prometheus.NewDesc(
+			"fortigate_interface_aggregate_members_link_up",
+			"Number of member interfaces of an aggregate interface that have link",
+			[]string{"vdom", "aggregate"}, nil,
+		)
+		mMinLinks = prometheus.NewDesc(
+			"fortigate_interface_aggregate_min_links",
+			"Minimum number of active member interfaces for the aggregate interface to be up",
+			[]string{"vdom", "aggregate"}, nil,
+		)
+	)
+
+	type ifMember struct {
+		Name string `json:"interface-name"`
+	}
+	type ifConfig struct {
+		Name     string
+		VDOM     string
+		Type     string
+		MinLinks float64    `json:"min-links"`
+		Member   []ifMember `json:"member"`
+	}
+	type ifConfigResponse struct {
+		Results []ifConfig
+	}
+	var rc ifConfigResponse
+
+	if err := c.Get("api/v2/cmdb/system/interface", "format=name|vdom|type|min-links|member", &rc); err != nil {
+		return nil, err
+	}
+
+	aggregates := []ifConfig{}
+	for _, cfg := range rc.Results {
+		if cfg.Type == "aggregate" {
+			aggregates = append(aggregates, cfg)
+		}
+	}
+	if len(aggregates) == 0 {
+		return nil, nil
+	}
+
+	type ifResult struct {
+		Name string
+		Link bool
+	}
+	type ifResponse struct {
+		Results map[string]ifResult
+		VDOM    string
+	}
+	var r []ifResponse
+
+	if err := c.Get("api/v2/monitor/system/interface/select", "vdom=*&include_vlan=true&include_aggregate=true", &r); err != nil {
+		return nil, err
+	}
+	link := map[string]bool{}
+	for _, v := range r {
+		for _, ir := range v.Results {
+			link[ir.Name] = ir.Link
+		}
+	}
+
+	m := []prometheus.Metric{}
+	for _, a := range aggregates {
+		linkUp := 0.0
+		for _, mb := range a.Member {
+			// The REST API does not expose the LACP partner state, only
+			// whether a member has link
+			up := 0.0
+			if link[mb.Name] {
+				up = 1.0
+				linkUp++
+			}
+			m = append(m, prometheus.MustNewConstMetric(mMemberLinkUp, prometheus.GaugeValue, up, a.VDOM, a.Name, mb.Name))
+		}
+		m = append(m, prometheus.MustNewConstMetric(mMembers, prometheus.GaugeValue, float64(len(a.Member)), a.VDOM, a.Name))
+		m = append(m, prometheus.MustNewConstMetric(mMembersLinkUp, prometheus.GaugeValue, linkUp, a.VDOM, a.Name))
+		m = append(m, prometheus.MustNewConstMetric(mMinLinks, prometheus.GaugeValue, a.MinLinks, a.VDOM, a.Name))
+	}
+	return m, nil
+}
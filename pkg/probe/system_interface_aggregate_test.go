@@ -0,0 +1,53 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemInterfaceAggregate(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/interface/select", "testdata/interface.jsonnet")
+	c.prepare("api/v2/cmdb/system/interface", "testdata/interface-config.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemInterfaceAggregate, c, r) {
+		t.Errorf("probeSystemInterfaceAggregate() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_interface_aggregate_member_link_up Whether the member interface of the aggregate has link, FortiOS does not expose the LACP state through the REST API
+	# TYPE fortigate_interface_aggregate_member_link_up gauge
+	fortigate_interface_aggregate_member_link_up{aggregate="a",member="internal1",vdom="main"} 1
+	fortigate_interface_aggregate_member_link_up{aggregate="a",member="internal2",vdom="main"} 1
+	fortigate_interface_aggregate_member_link_up{aggregate="a",member="internal3",vdom="main"} 0
+	# HELP fortigate_interface_aggregate_members Number of configured member interfaces of an aggregate interface
+	# TYPE fortigate_interface_aggregate_members gauge
+	fortigate_interface_aggregate_members{aggregate="a",vdom="main"} 3
+	# HELP fortigate_interface_aggregate_members_link_up Number of member interfaces of an aggregate interface that have link
+	# TYPE fortigate_interface_aggregate_members_link_up gauge
+	fortigate_interface_aggregate_members_link_up{aggregate="a",vdom="main"} 2
+	# HELP fortigate_interface_aggregate_min_links Minimum number of active member interfaces for the aggregate interface to be up
+	# TYPE fortigate_interface_aggregate_min_links gauge
+	fortigate_interface_aggregate_min_links{aggregate="a",vdom="main"} 2
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
@@ -24,12 +25,66 @@ import (
 func TestSystemInterfaces(t *testing.T) {
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/system/interface/select", "testdata/interface.jsonnet")
+	c.prepare("api/v2/cmdb/system/interface", "testdata/interface-config.jsonnet")
 	r := prometheus.NewPedanticRegistry()
 	if !testProbe(probeSystemInterface, c, r) {
 		t.Errorf("probeSystemInterface() returned non-success")
 	}
 
 	em := `
+	# HELP fortigate_interface_admin_up Whether the interface is administratively up or not
+	# TYPE fortigate_interface_admin_up gauge
+	fortigate_interface_admin_up{alias="",name="b",parent="",vdom="root"} 1
+	fortigate_interface_admin_up{alias="",name="internal1",parent="",vdom="infra"} 1
+	fortigate_interface_admin_up{alias="",name="internal2",parent="",vdom="infra"} 1
+	fortigate_interface_admin_up{alias="",name="internal3",parent="",vdom="root"} 0
+	fortigate_interface_admin_up{alias="",name="internal4",parent="",vdom="root"} 0
+	fortigate_interface_admin_up{alias="",name="internal5",parent="",vdom="root"} 1
+	fortigate_interface_admin_up{alias="",name="npu0_vlink0",parent="",vdom="root"} 1
+	fortigate_interface_admin_up{alias="",name="npu0_vlink1",parent="",vdom="root"} 1
+	fortigate_interface_admin_up{alias="",name="vlan-knx",parent="downlink",vdom="knx"} 1
+	fortigate_interface_admin_up{alias="",name="vlan-ocp-knx",parent="a",vdom="knx"} 1
+	fortigate_interface_admin_up{alias="",name="wan1",parent="",vdom="main"} 1
+	fortigate_interface_admin_up{alias="",name="wan2",parent="",vdom="root"} 0
+	fortigate_interface_admin_up{alias="(mgmt)",name="dmz",parent="",vdom="root"} 1
+	fortigate_interface_admin_up{alias="(ocp-mgmt)",name="a",parent="",vdom="main"} 1
+	fortigate_interface_admin_up{alias="(vlan-infra-mgmt)",name="downlink",parent="",vdom="infra"} 1
+	# HELP fortigate_interface_duplex Duplex mode negotiated on the port (0 - Half, 1 - Full)
+	# TYPE fortigate_interface_duplex gauge
+	fortigate_interface_duplex{alias="",name="b",parent="",vdom="root"} 0
+	fortigate_interface_duplex{alias="",name="internal1",parent="",vdom="infra"} 1
+	fortigate_interface_duplex{alias="",name="internal2",parent="",vdom="infra"} 1
+	fortigate_interface_duplex{alias="",name="internal3",parent="",vdom="root"} 0
+	fortigate_interface_duplex{alias="",name="internal4",parent="",vdom="root"} 0
+	fortigate_interface_duplex{alias="",name="internal5",parent="",vdom="root"} 0
+	fortigate_interface_duplex{alias="",name="modem",parent="",vdom="root"} 0
+	fortigate_interface_duplex{alias="",name="npu0_vlink0",parent="",vdom="root"} 1
+	fortigate_interface_duplex{alias="",name="npu0_vlink1",parent="",vdom="root"} 1
+	fortigate_interface_duplex{alias="",name="vlan-knx",parent="downlink",vdom="knx"} 1
+	fortigate_interface_duplex{alias="",name="vlan-ocp-knx",parent="a",vdom="knx"} 1
+	fortigate_interface_duplex{alias="",name="wan1",parent="",vdom="main"} 1
+	fortigate_interface_duplex{alias="",name="wan2",parent="",vdom="root"} 0
+	fortigate_interface_duplex{alias="(mgmt)",name="dmz",parent="",vdom="root"} 1
+	fortigate_interface_duplex{alias="(ocp-mgmt)",name="a",parent="",vdom="main"} 1
+	fortigate_interface_duplex{alias="(vlan-infra-mgmt)",name="downlink",parent="",vdom="infra"} 1
+	# HELP fortigate_interface_info Configuration and addressing of the interface
+	# TYPE fortigate_interface_info gauge
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="",name="modem",parent="",role="",type="",vdom="root"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="b",parent="",role="undefined",type="loopback",vdom="root"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="internal1",parent="",role="lan",type="physical",vdom="infra"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="internal2",parent="",role="lan",type="physical",vdom="infra"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="internal3",parent="",role="lan",type="physical",vdom="root"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="internal4",parent="",role="lan",type="physical",vdom="root"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="internal5",parent="",role="lan",type="physical",vdom="root"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="npu0_vlink0",parent="",role="undefined",type="physical",vdom="root"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="npu0_vlink1",parent="",role="undefined",type="physical",vdom="root"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="vlan-knx",parent="downlink",role="lan",type="vlan",vdom="knx"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="vlan-ocp-knx",parent="a",role="lan",type="vlan",vdom="knx"} 1
+	fortigate_interface_info{address="",alias="",mac="00:00:00:00:00:00",mtu="1500",name="wan2",parent="",role="wan",type="physical",vdom="root"} 1
+	fortigate_interface_info{address="10.0.255.1/29",alias="(ocp-mgmt)",mac="00:00:00:00:00:00",mtu="1500",name="a",parent="",role="undefined",type="aggregate",vdom="main"} 1
+	fortigate_interface_info{address="10.0.6.254/24",alias="(mgmt)",mac="00:00:00:00:00:00",mtu="1500",name="dmz",parent="",role="dmz",type="physical",vdom="root"} 1
+	fortigate_interface_info{address="178.132.72.38/24",alias="",mac="00:00:00:00:00:00",mtu="1500",name="wan1",parent="",role="wan",type="physical",vdom="main"} 1
+	fortigate_interface_info{address="192.168.1.1/24",alias="(vlan-infra-mgmt)",mac="00:00:00:00:00:00",mtu="9000",name="downlink",parent="",role="lan",type="physical",vdom="infra"} 1
 	# HELP fortigate_interface_link_up Whether the link is up or not (not taking into account admin status)
 	# TYPE fortigate_interface_link_up gauge
 	fortigate_interface_link_up{alias="",name="b",parent="",vdom="root"} 0
@@ -179,3 +234,20 @@ func TestSystemInterfaces(t *testing.T) {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func TestSystemInterfacesWithoutConfig(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/interface/select", "testdata/interface.jsonnet")
+	c.prepareError("api/v2/cmdb/system/interface", &http.APIError{StatusCode: 403})
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemInterface, c, r) {
+		t.Errorf("probeSystemInterface() returned non-success")
+	}
+
+	if n, err := testutil.GatherAndCount(r, "fortigate_interface_admin_up"); err != nil || n != 0 {
+		t.Errorf("expected no admin status without the configuration, got %d (err %v)", n, err)
+	}
+	if n, err := testutil.GatherAndCount(r, "fortigate_interface_link_up", "fortigate_interface_info"); err != nil || n == 0 {
+		t.Errorf("expected interface metrics without the configuration, got %d (err %v)", n, err)
+	}
+}
@@ -15,13 +15,24 @@ package probe
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeSystemResourceUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+type resUsage struct {
+	Current float64
+}
+
+// appendCurrent adds a gauge with the current value of a resource, if it was reported
+func appendCurrent(m []prometheus.Metric, desc *prometheus.Desc, usage []resUsage, labels ...string) []prometheus.Metric {
+	if len(usage) == 0 {
+		return m
+	}
+	return append(m, prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, usage[0].Current, labels...))
+}
+
+func probeSystemResourceUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		mResCPU = prometheus.NewDesc(
 			"fortigate_cpu_usage_ratio",
@@ -38,27 +49,44 @@ func probeSystemResourceUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 			"Current amount of sessions, per IP version",
 			[]string{"protocol"}, nil,
 		)
+		mResSetupRate = prometheus.NewDesc(
+			"fortigate_session_setup_rate",
+			"Current session setup rate in sessions per second, per IP version",
+			[]string{"protocol"}, nil,
+		)
+		mResNPUSession = prometheus.NewDesc(
+			"fortigate_npu_sessions",
+			"Current amount of sessions offloaded to the NPU, per IP version",
+			[]string{"protocol"}, nil,
+		)
+		mResNTurboSession = prometheus.NewDesc(
+			"fortigate_nturbo_sessions",
+			"Current amount of sessions offloaded to nTurbo, per IP version",
+			[]string{"protocol"}, nil,
+		)
+		mResLogRate = prometheus.NewDesc(
+			"fortigate_log_rate",
+			"Current rate of logs sent in logs per second, per destination",
+			[]string{"destination"}, nil,
+		)
 	)
 
-	type resUsage struct {
-		Current float64
-	}
 	type resContainer struct {
 		CPU []resUsage
 		Mem []resUsage
 		// Ignore "disk", we get that from log/current-disk-usage instead with better resolution
-		Session  []resUsage
-		Session6 []resUsage
-		// TODO(bluecmd): These are TODO
-		// Setuprate []resUsage
-		// Setuprate6 []resUsage
-		// NpuSession []resUsage `json:"npu_session"`
-		// NpuSession6 []resUsage `json:"npu_session6"`
-		// NturboSession []resUsage  `json:"nturbo_session"`
-		// NturboSession6 []resUsage `json:"nturbo_session6"`
-		// DiskLograte []resUsage `json:"disk_lograte"`
-		// FazLograte []resUsage `json:"faz_lograte"`
-		// ForticloudLograte []resUsage `json:"forticloud_lograte"`
+		Session    []resUsage
+		Session6   []resUsage
+		Setuprate  []resUsage
+		Setuprate6 []resUsage
+		// NPU and nTurbo sessions are only reported by models that have them
+		NpuSession        []resUsage `json:"npu_session"`
+		NpuSession6       []resUsage `json:"npu_session6"`
+		NturboSession     []resUsage `json:"nturbo_session"`
+		NturboSession6    []resUsage `json:"nturbo_session6"`
+		DiskLograte       []resUsage `json:"disk_lograte"`
+		FazLograte        []resUsage `json:"faz_lograte"`
+		ForticloudLograte []resUsage `json:"forticloud_lograte"`
 	}
 	type systemResourceUsage struct {
 		Results resContainer
@@ -67,8 +95,7 @@ func probeSystemResourceUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 	var sr systemResourceUsage
 
 	if err := c.Get("api/v2/monitor/system/resource/usage", "interval=1-min&scope=global", &sr); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	// CPU[0] is the average over all cores, ignore it
@@ -80,10 +107,19 @@ func probeSystemResourceUsage(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 	m = append(m, prometheus.MustNewConstMetric(mResMemory, prometheus.GaugeValue, float64(sr.Results.Mem[0].Current)/100.0))
 	m = append(m, prometheus.MustNewConstMetric(mResSession, prometheus.GaugeValue, float64(sr.Results.Session[0].Current), "ipv4"))
 	m = append(m, prometheus.MustNewConstMetric(mResSession, prometheus.GaugeValue, float64(sr.Results.Session6[0].Current), "ipv6"))
-	return m, true
+	m = appendCurrent(m, mResSetupRate, sr.Results.Setuprate, "ipv4")
+	m = appendCurrent(m, mResSetupRate, sr.Results.Setuprate6, "ipv6")
+	m = appendCurrent(m, mResNPUSession, sr.Results.NpuSession, "ipv4")
+	m = appendCurrent(m, mResNPUSession, sr.Results.NpuSession6, "ipv6")
+	m = appendCurrent(m, mResNTurboSession, sr.Results.NturboSession, "ipv4")
+	m = appendCurrent(m, mResNTurboSession, sr.Results.NturboSession6, "ipv6")
+	m = appendCurrent(m, mResLogRate, sr.Results.DiskLograte, "disk")
+	m = appendCurrent(m, mResLogRate, sr.Results.FazLograte, "fortianalyzer")
+	m = appendCurrent(m, mResLogRate, sr.Results.ForticloudLograte, "forticloud")
+	return m, nil
 }
 
-func probeSystemVDOMResources(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeSystemVDOMResources(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		mResCPU = prometheus.NewDesc(
 			"fortigate_vdom_cpu_usage_ratio",
@@ -100,23 +136,44 @@ func probeSystemVDOMResources(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 			"Current amount of sessions, per VDOM and IP version",
 			[]string{"vdom", "protocol"}, nil,
 		)
+		mResSetupRate = prometheus.NewDesc(
+			"fortigate_vdom_session_setup_rate",
+			"Current session setup rate in sessions per second, per VDOM and IP version",
+			[]string{"vdom", "protocol"}, nil,
+		)
+		mResNPUSession = prometheus.NewDesc(
+			"fortigate_vdom_npu_sessions",
+			"Current amount of sessions offloaded to the NPU, per VDOM and IP version",
+			[]string{"vdom", "protocol"}, nil,
+		)
+		mResNTurboSession = prometheus.NewDesc(
+			"fortigate_vdom_nturbo_sessions",
+			"Current amount of sessions offloaded to nTurbo, per VDOM and IP version",
+			[]string{"vdom", "protocol"}, nil,
+		)
+		mResLogRate = prometheus.NewDesc(
+			"fortigate_vdom_log_rate",
+			"Current rate of logs sent in logs per second, per VDOM and destination",
+			[]string{"vdom", "destination"}, nil,
+		)
 	)
 
-	type resUsage struct {
-		Current float64
-	}
 	type resContainer struct {
 		CPU []resUsage
 		Mem []resUsage
 		// Ignore "disk", we get that from log/current-disk-usage instead with better resolution
-		Session  []resUsage
-		Session6 []resUsage
-		// TODO(bluecmd): These are TODO
-		// Setuprate []resUsage
-		// Setuprate6 []resUsage
-		// DiskLograte []resUsage `json:"disk_lograte"`
-		// FazLograte []resUsage `json:"faz_lograte"`
-		// ForticloudLograte []resUsage `json:"forticloud_lograte"`
+		Session    []resUsage
+		Session6   []resUsage
+		Setuprate  []resUsage
+		Setuprate6 []resUsage
+		// NPU and nTurbo sessions are only reported by models that have them
+		NpuSession        []resUsage `json:"npu_session"`
+		NpuSession6       []resUsage `json:"npu_session6"`
+		NturboSession     []resUsage `json:"nturbo_session"`
+		NturboSession6    []resUsage `json:"nturbo_session6"`
+		DiskLograte       []resUsage `json:"disk_lograte"`
+		FazLograte        []resUsage `json:"faz_lograte"`
+		ForticloudLograte []resUsage `json:"forticloud_lograte"`
 	}
 	type systemResourceUsage struct {
 		Results resContainer
@@ -125,8 +182,7 @@ func probeSystemVDOMResources(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 	var sr []systemResourceUsage
 
 	if err := c.Get("api/v2/monitor/system/resource/usage", "interval=1-min&vdom=*", &sr); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 	m := []prometheus.Metric{}
 	for _, s := range sr {
@@ -134,6 +190,15 @@ func probeSystemVDOMResources(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 		m = append(m, prometheus.MustNewConstMetric(mResMemory, prometheus.GaugeValue, float64(s.Results.Mem[0].Current)/100.0, s.VDOM))
 		m = append(m, prometheus.MustNewConstMetric(mResSession, prometheus.GaugeValue, float64(s.Results.Session[0].Current), s.VDOM, "ipv4"))
 		m = append(m, prometheus.MustNewConstMetric(mResSession, prometheus.GaugeValue, float64(s.Results.Session6[0].Current), s.VDOM, "ipv6"))
+		m = appendCurrent(m, mResSetupRate, s.Results.Setuprate, s.VDOM, "ipv4")
+		m = appendCurrent(m, mResSetupRate, s.Results.Setuprate6, s.VDOM, "ipv6")
+		m = appendCurrent(m, mResNPUSession, s.Results.NpuSession, s.VDOM, "ipv4")
+		m = appendCurrent(m, mResNPUSession, s.Results.NpuSession6, s.VDOM, "ipv6")
+		m = appendCurrent(m, mResNTurboSession, s.Results.NturboSession, s.VDOM, "ipv4")
+		m = appendCurrent(m, mResNTurboSession, s.Results.NturboSession6, s.VDOM, "ipv6")
+		m = appendCurrent(m, mResLogRate, s.Results.DiskLograte, s.VDOM, "disk")
+		m = appendCurrent(m, mResLogRate, s.Results.FazLograte, s.VDOM, "fortianalyzer")
+		m = appendCurrent(m, mResLogRate, s.Results.ForticloudLograte, s.VDOM, "forticloud")
 	}
-	return m, true
+	return m, nil
 }
@@ -40,11 +40,44 @@ func TestSystemResourceUsage(t *testing.T) {
 	# TYPE fortigate_current_sessions gauge
 	fortigate_current_sessions{protocol="ipv4"} 5
 	fortigate_current_sessions{protocol="ipv6"} 1
+	# HELP fortigate_session_setup_rate Current session setup rate in sessions per second, per IP version
+	# TYPE fortigate_session_setup_rate gauge
+	fortigate_session_setup_rate{protocol="ipv4"} 0
+	fortigate_session_setup_rate{protocol="ipv6"} 0
+	# HELP fortigate_log_rate Current rate of logs sent in logs per second, per destination
+	# TYPE fortigate_log_rate gauge
+	fortigate_log_rate{destination="disk"} 0
+	fortigate_log_rate{destination="fortianalyzer"} 0
+	fortigate_log_rate{destination="forticloud"} 0
 	`
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func TestSystemResourceUsageNPU(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/resource/usage", "testdata/usage-npu.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemResourceUsage, c, r) {
+		t.Errorf("probeSystemResourceUsage() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_npu_sessions Current amount of sessions offloaded to the NPU, per IP version
+	# TYPE fortigate_npu_sessions gauge
+	fortigate_npu_sessions{protocol="ipv4"} 1204
+	fortigate_npu_sessions{protocol="ipv6"} 31
+	# HELP fortigate_nturbo_sessions Current amount of sessions offloaded to nTurbo, per IP version
+	# TYPE fortigate_nturbo_sessions gauge
+	fortigate_nturbo_sessions{protocol="ipv4"} 402
+	fortigate_nturbo_sessions{protocol="ipv6"} 0
+	`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_npu_sessions", "fortigate_nturbo_sessions"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
 func TestSystemVDOMResources(t *testing.T) {
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/system/resource/usage", "testdata/usage-vdom.jsonnet")
@@ -68,6 +101,20 @@ func TestSystemVDOMResources(t *testing.T) {
 	fortigate_vdom_current_sessions{protocol="ipv4",vdom="root"} 18
 	fortigate_vdom_current_sessions{protocol="ipv6",vdom="FG-traffic"} 7
 	fortigate_vdom_current_sessions{protocol="ipv6",vdom="root"} 7
+	# HELP fortigate_vdom_session_setup_rate Current session setup rate in sessions per second, per VDOM and IP version
+	# TYPE fortigate_vdom_session_setup_rate gauge
+	fortigate_vdom_session_setup_rate{protocol="ipv4",vdom="FG-traffic"} 0
+	fortigate_vdom_session_setup_rate{protocol="ipv4",vdom="root"} 1
+	fortigate_vdom_session_setup_rate{protocol="ipv6",vdom="FG-traffic"} 1
+	fortigate_vdom_session_setup_rate{protocol="ipv6",vdom="root"} 1
+	# HELP fortigate_vdom_log_rate Current rate of logs sent in logs per second, per VDOM and destination
+	# TYPE fortigate_vdom_log_rate gauge
+	fortigate_vdom_log_rate{destination="disk",vdom="FG-traffic"} 0
+	fortigate_vdom_log_rate{destination="disk",vdom="root"} 0
+	fortigate_vdom_log_rate{destination="fortianalyzer",vdom="FG-traffic"} 0
+	fortigate_vdom_log_rate{destination="fortianalyzer",vdom="root"} 0
+	fortigate_vdom_log_rate{destination="forticloud",vdom="FG-traffic"} 0
+	fortigate_vdom_log_rate{destination="forticloud",vdom="root"} 0
 	`
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
 		t.Fatalf("metric compare: err %v", err)
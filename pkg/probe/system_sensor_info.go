@@ -14,8 +14,6 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -30,7 +28,7 @@ type SystemSensorInfo struct {
 	Results []SystemSensorInfoResults `json:"results"`
 }
 
-func probeSystemSensorInfo(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeSystemSensorInfo(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		sensorTemperature = prometheus.NewDesc(
 			"fortigate_sensor_temperature_celsius",
@@ -51,8 +49,7 @@ func probeSystemSensorInfo(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 
 	var res SystemSensorInfo
 	if err := c.Get("api/v2/monitor/system/sensor-info", "vdom=root", &res); err != nil {
-		log.Printf("Warning: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -67,5 +64,5 @@ func probeSystemSensorInfo(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus
 		}
 	}
 
-	return m, true
+	return m, nil
 }
@@ -14,13 +14,11 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeSystemTime(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeSystemTime(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		mTime = prometheus.NewDesc(
 			"fortigate_time_seconds",
@@ -40,12 +38,11 @@ func probeSystemTime(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metri
 	var stime systemTime
 
 	if err := c.Get("api/v2/monitor/system/time", "vdom=root", &stime); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{
 		prometheus.MustNewConstMetric(mTime, prometheus.GaugeValue, stime.Results.Time),
 	}
-	return m, true
+	return m, nil
 }
@@ -14,7 +14,6 @@
 package probe
 
 import (
-	"log"
 	"strconv"
 
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
@@ -33,7 +32,7 @@ type UserFsso struct {
 	VDOM    string            `json:"vdom"`
 }
 
-func probeUserFsso(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeUserFsso(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		FssoUsers = prometheus.NewDesc(
 			"fortigate_user_fsso_info",
@@ -44,8 +43,7 @@ func probeUserFsso(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric,
 
 	var res []UserFsso
 	if err := c.Get("api/v2/monitor/user/fsso", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -59,5 +57,5 @@ func probeUserFsso(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric,
 		}
 	}
 
-	return m, true
+	return m, nil
 }
@@ -16,12 +16,13 @@ package probe
 import (
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeVPNIPSec(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeVPNIPSec(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		status = prometheus.NewDesc(
 			"fortigate_ipsec_tunnel_up",
@@ -38,19 +39,59 @@ func probeVPNIPSec(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric,
 			"Total number of bytes received over the IPsec tunnel",
 			[]string{"vdom", "name", "p2serial", "parent"}, nil,
 		)
+		selector = prometheus.NewDesc(
+			"fortigate_ipsec_tunnel_selector_info",
+			"Phase 2 selectors of the IPsec tunnel",
+			[]string{"vdom", "name", "p2serial", "parent", "source", "destination"}, nil,
+		)
+		p1Info = prometheus.NewDesc(
+			"fortigate_ipsec_phase1_info",
+			"Information about the IPsec phase 1 tunnel",
+			[]string{"vdom", "name", "remote_gateway", "ike_version"}, nil,
+		)
+		p1Uptime = prometheus.NewDesc(
+			"fortigate_ipsec_phase1_uptime_seconds",
+			"Number of seconds since the IPsec phase 1 tunnel was created",
+			[]string{"vdom", "name"}, nil,
+		)
+		dialupTunnels = prometheus.NewDesc(
+			"fortigate_ipsec_dialup_tunnels",
+			"Number of connected dialup IPsec tunnels, per parent tunnel",
+			[]string{"vdom", "parent"}, nil,
+		)
+		dialupTransmitted = prometheus.NewDesc(
+			"fortigate_ipsec_dialup_transmit_bytes",
+			"Number of bytes transmitted over the connected dialup IPsec tunnels, per parent tunnel",
+			[]string{"vdom", "parent"}, nil,
+		)
+		dialupReceived = prometheus.NewDesc(
+			"fortigate_ipsec_dialup_receive_bytes",
+			"Number of bytes received over the connected dialup IPsec tunnels, per parent tunnel",
+			[]string{"vdom", "parent"}, nil,
+		)
 	)
 
+	type p2selector struct {
+		Subnet string `json:"subnet"`
+	}
 	type proxyid struct {
-		Name     string  `json:"p2name"`
-		P2serial int     `json:"p2serial"`
-		Status   string  `json:"status"`
-		Incoming float64 `json:"incoming_bytes"`
-		Outgoing float64 `json:"outgoing_bytes"`
+		Name     string       `json:"p2name"`
+		P2serial int          `json:"p2serial"`
+		Status   string       `json:"status"`
+		Incoming float64      `json:"incoming_bytes"`
+		Outgoing float64      `json:"outgoing_bytes"`
+		Src      []p2selector `json:"proxy_src"`
+		Dst      []p2selector `json:"proxy_dst"`
 	}
 	type tunnel struct {
-		Name    string    `json:"name"`
-		Type    string    `json:"type"`
-		ProxyID []proxyid `json:"proxyid"`
+		Name         string    `json:"name"`
+		Type         string    `json:"type"`
+		Parent       string    `json:"parent"`
+		CreationTime float64   `json:"creation_time"`
+		RemoteGW     string    `json:"rgwy"`
+		Incoming     float64   `json:"incoming_bytes"`
+		Outgoing     float64   `json:"outgoing_bytes"`
+		ProxyID      []proxyid `json:"proxyid"`
 	}
 	type ipsecResult struct {
 		Results []tunnel `json:"results"`
@@ -58,20 +99,74 @@ func probeVPNIPSec(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric,
 	}
 	var res []ipsecResult
 	if err := c.Get("api/v2/monitor/vpn/ipsec", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
+	}
+
+	type phase1 struct {
+		Name       string `json:"name"`
+		IKEVersion string `json:"ike-version"`
+	}
+	type phase1Result struct {
+		Results []phase1 `json:"results"`
+		VDOM    string
+	}
+	var p1res []phase1Result
+	if err := c.Get("api/v2/cmdb/vpn.ipsec/phase1-interface", "vdom=*&format=name|ike-version", &p1res); err != nil {
+		// The tunnel metrics do not depend on it, e.g. the token may lack cmdb read access
+		log.Printf("Warning: failed to fetch IPsec phase 1 configuration, ike_version will be empty: %v", err)
+	}
+	ikeVersion := map[string]string{}
+	for _, v := range p1res {
+		for _, p := range v.Results {
+			ikeVersion[v.VDOM+"/"+p.Name] = p.IKEVersion
+		}
+	}
+
+	subnets := func(s []p2selector) string {
+		r := make([]string, len(s))
+		for i, s := range s {
+			r[i] = s.Subnet
+		}
+		return strings.Join(r, ",")
+	}
+
+	type dialup struct {
+		tunnels  float64
+		incoming float64
+		outgoing float64
 	}
 
 	m := []prometheus.Metric{}
 	for _, v := range res {
+		dialups := map[string]*dialup{}
 		for _, i := range v.Results {
 			/*
-			  type 'dialup' seems to be client vpn.
-			  Not sure exactly what the difference is between probeVPNSsl
+			  type 'dialup' is used for the tunnels of remote access clients. There is
+			  one such tunnel per connected client, so they are only exported in aggregate
+			  per parent tunnel to keep the cardinality down.
 			*/
 			if i.Type == "dialup" {
+				parent := i.Parent
+				if parent == "" {
+					parent = i.Name
+				}
+				d, ok := dialups[parent]
+				if !ok {
+					d = &dialup{}
+					dialups[parent] = d
+				}
+				for _, t := range i.ProxyID {
+					if t.Status == "up" {
+						d.tunnels++
+						d.incoming += i.Incoming
+						d.outgoing += i.Outgoing
+						break
+					}
+				}
 				continue
 			}
+			m = append(m, prometheus.MustNewConstMetric(p1Info, prometheus.GaugeValue, 1, v.VDOM, i.Name, i.RemoteGW, ikeVersion[v.VDOM+"/"+i.Name]))
+			m = append(m, prometheus.MustNewConstMetric(p1Uptime, prometheus.GaugeValue, i.CreationTime, v.VDOM, i.Name))
 			for _, t := range i.ProxyID {
 				s := 0.0
 				if t.Status == "up" {
@@ -80,8 +175,15 @@ func probeVPNIPSec(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric,
 				m = append(m, prometheus.MustNewConstMetric(status, prometheus.GaugeValue, s, v.VDOM, t.Name, strconv.Itoa(t.P2serial), i.Name))
 				m = append(m, prometheus.MustNewConstMetric(transmitted, prometheus.CounterValue, t.Outgoing, v.VDOM, t.Name, strconv.Itoa(t.P2serial), i.Name))
 				m = append(m, prometheus.MustNewConstMetric(received, prometheus.CounterValue, t.Incoming, v.VDOM, t.Name, strconv.Itoa(t.P2serial), i.Name))
+				m = append(m, prometheus.MustNewConstMetric(selector, prometheus.GaugeValue, 1, v.VDOM, t.Name, strconv.Itoa(t.P2serial), i.Name, subnets(t.Src), subnets(t.Dst)))
 			}
 		}
+
+		for p, d := range dialups {
+			m = append(m, prometheus.MustNewConstMetric(dialupTunnels, prometheus.GaugeValue, d.tunnels, v.VDOM, p))
+			m = append(m, prometheus.MustNewConstMetric(dialupTransmitted, prometheus.GaugeValue, d.outgoing, v.VDOM, p))
+			m = append(m, prometheus.MustNewConstMetric(dialupReceived, prometheus.GaugeValue, d.incoming, v.VDOM, p))
+		}
 	}
-	return m, true
+	return m, nil
 }
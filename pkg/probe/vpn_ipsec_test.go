@@ -17,6 +17,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
@@ -24,16 +25,27 @@ import (
 func TestVPNIPSec(t *testing.T) {
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/vpn/ipsec", "testdata/ipsec.jsonnet")
+	c.prepare("api/v2/cmdb/vpn.ipsec/phase1-interface", "testdata/ipsec-phase1.jsonnet")
 	r := prometheus.NewPedanticRegistry()
 	if !testProbe(probeVPNIPSec, c, r) {
 		t.Errorf("probeVPNIPSec() returned non-success")
 	}
 
 	em := `
+	# HELP fortigate_ipsec_phase1_info Information about the IPsec phase 1 tunnel
+	# TYPE fortigate_ipsec_phase1_info gauge
+	fortigate_ipsec_phase1_info{ike_version="2",name="tunnel_1",remote_gateway="1.2.3.4",vdom="root"} 1
+	# HELP fortigate_ipsec_phase1_uptime_seconds Number of seconds since the IPsec phase 1 tunnel was created
+	# TYPE fortigate_ipsec_phase1_uptime_seconds gauge
+	fortigate_ipsec_phase1_uptime_seconds{name="tunnel_1",vdom="root"} 270801
 	# HELP fortigate_ipsec_tunnel_receive_bytes_total Total number of bytes received over the IPsec tunnel
 	# TYPE fortigate_ipsec_tunnel_receive_bytes_total counter
 	fortigate_ipsec_tunnel_receive_bytes_total{name="tunnel_1-sub",p2serial="1",parent="tunnel_1",vdom="root"} 1.429824e+07
 	fortigate_ipsec_tunnel_receive_bytes_total{name="tunnel_1-sub",p2serial="12",parent="tunnel_1",vdom="root"} 1.429824e+07
+	# HELP fortigate_ipsec_tunnel_selector_info Phase 2 selectors of the IPsec tunnel
+	# TYPE fortigate_ipsec_tunnel_selector_info gauge
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0/0.0.0.0",name="tunnel_1-sub",p2serial="1",parent="tunnel_1",source="0.0.0.0/0.0.0.0",vdom="root"} 1
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0/0.0.0.0",name="tunnel_1-sub",p2serial="12",parent="tunnel_1",source="0.0.0.0/0.0.0.0",vdom="root"} 1
 	# HELP fortigate_ipsec_tunnel_transmit_bytes_total Total number of bytes transmitted over the IPsec tunnel
 	# TYPE fortigate_ipsec_tunnel_transmit_bytes_total counter
 	fortigate_ipsec_tunnel_transmit_bytes_total{name="tunnel_1-sub",p2serial="1",parent="tunnel_1",vdom="root"} 1.424856e+07
@@ -42,7 +54,6 @@ func TestVPNIPSec(t *testing.T) {
 	# TYPE fortigate_ipsec_tunnel_up gauge
 	fortigate_ipsec_tunnel_up{name="tunnel_1-sub",p2serial="1",parent="tunnel_1",vdom="root"} 1
 	fortigate_ipsec_tunnel_up{name="tunnel_1-sub",p2serial="12",parent="tunnel_1",vdom="root"} 0
-
 	`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
@@ -53,12 +64,19 @@ func TestVPNIPSec(t *testing.T) {
 func TestVPNIPSecWithCommonP2Names(t *testing.T) {
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/vpn/ipsec", "testdata/ipsec-common-p2.jsonnet")
+	c.prepare("api/v2/cmdb/vpn.ipsec/phase1-interface", "testdata/ipsec-phase1.jsonnet")
 	r := prometheus.NewPedanticRegistry()
 	if !testProbe(probeVPNIPSec, c, r) {
 		t.Errorf("probeVPNIPSec() returned non-success")
 	}
 
 	em := `
+	# HELP fortigate_ipsec_phase1_info Information about the IPsec phase 1 tunnel
+	# TYPE fortigate_ipsec_phase1_info gauge
+	fortigate_ipsec_phase1_info{ike_version="1",name="My VPN",remote_gateway="1.2.3.4",vdom="root"} 1
+	# HELP fortigate_ipsec_phase1_uptime_seconds Number of seconds since the IPsec phase 1 tunnel was created
+	# TYPE fortigate_ipsec_phase1_uptime_seconds gauge
+	fortigate_ipsec_phase1_uptime_seconds{name="My VPN",vdom="root"} 3.978e+06
 	# HELP fortigate_ipsec_tunnel_receive_bytes_total Total number of bytes received over the IPsec tunnel
 	# TYPE fortigate_ipsec_tunnel_receive_bytes_total counter
 	fortigate_ipsec_tunnel_receive_bytes_total{name="CommonP2",p2serial="22",parent="My VPN",vdom="root"} 0
@@ -67,6 +85,14 @@ func TestVPNIPSecWithCommonP2Names(t *testing.T) {
 	fortigate_ipsec_tunnel_receive_bytes_total{name="CommonP2",p2serial="25",parent="My VPN",vdom="root"} 1.581264e+06
 	fortigate_ipsec_tunnel_receive_bytes_total{name="mgmt",p2serial="1",parent="My VPN",vdom="root"} 0
 	fortigate_ipsec_tunnel_receive_bytes_total{name="some-network",p2serial="14",parent="My VPN",vdom="root"} 274832
+	# HELP fortigate_ipsec_tunnel_selector_info Phase 2 selectors of the IPsec tunnel
+	# TYPE fortigate_ipsec_tunnel_selector_info gauge
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0-255.255.255.255",name="CommonP2",p2serial="23",parent="My VPN",source="1.2.112.0-1.2.127.255",vdom="root"} 1
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0-255.255.255.255",name="CommonP2",p2serial="24",parent="My VPN",source="1.2.128.0-1.2.135.255",vdom="root"} 1
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0-255.255.255.255",name="CommonP2",p2serial="25",parent="My VPN",source="1.2.109.0-1.2.109.255",vdom="root"} 1
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0/0.0.0.0",name="CommonP2",p2serial="22",parent="My VPN",source="1.2.128.0/255.255.248.0,1.2.112.0/255.255.240.0,1.2.40.0/255.255.255.0,1.2.109.1/255.255.255.0",vdom="root"} 1
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0/0.0.0.0",name="mgmt",p2serial="1",parent="My VPN",source="1.2.101.2/255.255.255.255",vdom="root"} 1
+	fortigate_ipsec_tunnel_selector_info{destination="0.0.0.0/0.0.0.0",name="some-network",p2serial="14",parent="My VPN",source="1.2.40.0/255.255.255.0",vdom="root"} 1
 	# HELP fortigate_ipsec_tunnel_transmit_bytes_total Total number of bytes transmitted over the IPsec tunnel
 	# TYPE fortigate_ipsec_tunnel_transmit_bytes_total counter
 	fortigate_ipsec_tunnel_transmit_bytes_total{name="CommonP2",p2serial="22",parent="My VPN",vdom="root"} 0
@@ -89,3 +115,53 @@ func TestVPNIPSecWithCommonP2Names(t *testing.T) {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func TestVPNIPSecDialup(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/vpn/ipsec", "testdata/ipsec-dialup.jsonnet")
+	c.prepare("api/v2/cmdb/vpn.ipsec/phase1-interface", "testdata/ipsec-phase1.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeVPNIPSec, c, r) {
+		t.Errorf("probeVPNIPSec() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_ipsec_dialup_receive_bytes Number of bytes received over the connected dialup IPsec tunnels, per parent tunnel
+	# TYPE fortigate_ipsec_dialup_receive_bytes gauge
+	fortigate_ipsec_dialup_receive_bytes{parent="Dialup",vdom="root"} 1300
+	# HELP fortigate_ipsec_dialup_transmit_bytes Number of bytes transmitted over the connected dialup IPsec tunnels, per parent tunnel
+	# TYPE fortigate_ipsec_dialup_transmit_bytes gauge
+	fortigate_ipsec_dialup_transmit_bytes{parent="Dialup",vdom="root"} 2400
+	# HELP fortigate_ipsec_dialup_tunnels Number of connected dialup IPsec tunnels, per parent tunnel
+	# TYPE fortigate_ipsec_dialup_tunnels gauge
+	fortigate_ipsec_dialup_tunnels{parent="Dialup",vdom="root"} 2
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestVPNIPSecWithoutPhase1Config(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/vpn/ipsec", "testdata/ipsec.jsonnet")
+	c.prepareError("api/v2/cmdb/vpn.ipsec/phase1-interface", &http.APIError{StatusCode: 403})
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeVPNIPSec, c, r) {
+		t.Errorf("probeVPNIPSec() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_ipsec_phase1_info Information about the IPsec phase 1 tunnel
+	# TYPE fortigate_ipsec_phase1_info gauge
+	fortigate_ipsec_phase1_info{ike_version="",name="tunnel_1",remote_gateway="1.2.3.4",vdom="root"} 1
+	# HELP fortigate_ipsec_tunnel_up Status of IPsec tunnel (0 - Down, 1 - Up)
+	# TYPE fortigate_ipsec_tunnel_up gauge
+	fortigate_ipsec_tunnel_up{name="tunnel_1-sub",p2serial="1",parent="tunnel_1",vdom="root"} 1
+	fortigate_ipsec_tunnel_up{name="tunnel_1-sub",p2serial="12",parent="tunnel_1",vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_ipsec_phase1_info", "fortigate_ipsec_tunnel_up"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -15,14 +15,27 @@ package probe
 
 import (
 	"log"
+	"sort"
+	"strconv"
+	"time"
 
-	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type VPNUser struct {
-	UserName string `json:"user_name"`
+	Index              int                 `json:"index"`
+	UserName           string              `json:"user_name"`
+	RemoteHost         string              `json:"remote_host"`
+	LastLoginTimestamp float64             `json:"last_login_timestamp"`
+	Subsessions        []VPNUserSubsession `json:"subsessions"`
+}
+
+type VPNUserSubsession struct {
+	Mode     string  `json:"mode"`
+	AIP      string  `json:"aip"`
+	InBytes  float64 `json:"in_bytes"`
+	OutBytes float64 `json:"out_bytes"`
 }
 
 type VPNUsers struct {
@@ -30,9 +43,8 @@ type VPNUsers struct {
 	VDOM    string    `json:"vdom"`
 }
 
-func probeVPNSsl(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
-	savedConfig := config.GetConfig()
-	MaxVPNUsers := savedConfig.MaxVPNUsers
+func probeVPNSsl(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
+	MaxVPNUsers := maxVPNUsers(meta)
 
 	var (
 		vpncon = prometheus.NewDesc(
@@ -45,14 +57,41 @@ func probeVPNSsl(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, b
 			"Number of VPN users connections",
 			[]string{"vdom", "user"}, nil,
 		)
+		vpnSessionInfo = prometheus.NewDesc(
+			"fortigate_vpn_user_session_info",
+			"Information about a VPN user session",
+			[]string{"vdom", "user", "index", "source_ip", "tunnel_ip"}, nil,
+		)
+		vpnSessionDuration = prometheus.NewDesc(
+			"fortigate_vpn_user_session_duration_seconds",
+			"Number of seconds since the VPN user logged in",
+			[]string{"vdom", "user", "index"}, nil,
+		)
+		vpnSessionRx = prometheus.NewDesc(
+			"fortigate_vpn_user_session_receive_bytes_total",
+			"Total number of bytes received from the VPN user",
+			[]string{"vdom", "user", "index"}, nil,
+		)
+		vpnSessionTx = prometheus.NewDesc(
+			"fortigate_vpn_user_session_transmit_bytes_total",
+			"Total number of bytes transmitted to the VPN user",
+			[]string{"vdom", "user", "index"}, nil,
+		)
+		vpnSessionsTruncated = prometheus.NewDesc(
+			"fortigate_vpn_user_sessions_truncated",
+			"Set to 1 if there were more VPN user sessions than -max-vpn-users and not all are exported",
+			[]string{"vdom"}, nil,
+		)
 	)
 
 	var res []VPNUsers
 	if err := c.Get("api/v2/monitor/vpn/ssl", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
+	details := vpnUserDetails(meta)
+	now := time.Now()
+
 	m := []prometheus.Metric{}
 	for _, r := range res {
 		count := len(r.Results)
@@ -79,7 +118,41 @@ func probeVPNSsl(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, b
 				}
 			}
 		}
+
+		if details && MaxVPNUsers != 0 {
+			sessions := r.Results
+			truncated := 0.0
+			if count > MaxVPNUsers {
+				// Keep the oldest sessions so the exported set stays stable between scrapes
+				sort.Slice(sessions, func(i, j int) bool {
+					if sessions[i].LastLoginTimestamp != sessions[j].LastLoginTimestamp {
+						return sessions[i].LastLoginTimestamp < sessions[j].LastLoginTimestamp
+					}
+					// Session slots are reused, the index only breaks ties
+					return sessions[i].Index < sessions[j].Index
+				})
+				sessions = sessions[:MaxVPNUsers]
+				truncated = 1.0
+			}
+			m = append(m, prometheus.MustNewConstMetric(vpnSessionsTruncated, prometheus.GaugeValue, truncated, r.VDOM))
+			for _, s := range sessions {
+				index := strconv.Itoa(s.Index)
+				tunnelIP := ""
+				rx, tx := 0.0, 0.0
+				for _, ss := range s.Subsessions {
+					if tunnelIP == "" && ss.Mode == "Tunnel" {
+						tunnelIP = ss.AIP
+					}
+					rx += ss.InBytes
+					tx += ss.OutBytes
+				}
+				m = append(m, prometheus.MustNewConstMetric(vpnSessionInfo, prometheus.GaugeValue, 1, r.VDOM, s.UserName, index, s.RemoteHost, tunnelIP))
+				m = append(m, prometheus.MustNewConstMetric(vpnSessionDuration, prometheus.GaugeValue, now.Sub(time.Unix(int64(s.LastLoginTimestamp), 0)).Seconds(), r.VDOM, s.UserName, index))
+				m = append(m, prometheus.MustNewConstMetric(vpnSessionRx, prometheus.CounterValue, rx, r.VDOM, s.UserName, index))
+				m = append(m, prometheus.MustNewConstMetric(vpnSessionTx, prometheus.CounterValue, tx, r.VDOM, s.UserName, index))
+			}
+		}
 	}
 
-	return m, true
+	return m, nil
 }
@@ -14,8 +14,6 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -36,7 +34,7 @@ type VPNStats struct {
 	Version string     `json:"version"`
 }
 
-func probeVPNSslStats(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeVPNSslStats(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		vpnCurUsr = prometheus.NewDesc(
 			"fortigate_vpn_ssl_users",
@@ -57,8 +55,7 @@ func probeVPNSslStats(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metr
 
 	var res []VPNStats
 	if err := c.Get("api/v2/monitor/vpn/ssl/stats", "vdom=*", &res); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{}
@@ -68,5 +65,5 @@ func probeVPNSslStats(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metr
 		m = append(m, prometheus.MustNewConstMetric(vpnCurCon, prometheus.GaugeValue, float64(r.Results.Current.Connections), r.VDOM))
 	}
 
-	return m, true
+	return m, nil
 }
@@ -47,3 +47,118 @@ func TestVPNSsl(t *testing.T) {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func TestVPNSslUserDetails(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/vpn/ssl", "testdata/vpn.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	flag.Set("max-vpn-users", "10")
+	flag.Set("vpn-user-details", "true")
+	defer flag.Set("vpn-user-details", "false")
+	config.MustReInit()
+	if !testProbe(probeVPNSsl, c, r) {
+		t.Errorf("probeVPNSsl() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_vpn_user_session_info Information about a VPN user session
+	# TYPE fortigate_vpn_user_session_info gauge
+	fortigate_vpn_user_session_info{index="620",source_ip="1.2.3.4",tunnel_ip="2.3.4.5",user="user1",vdom="root"} 1
+	fortigate_vpn_user_session_info{index="630",source_ip="1.2.3.5",tunnel_ip="2.3.4.6",user="user2",vdom="root"} 1
+	fortigate_vpn_user_session_info{index="640",source_ip="1.2.3.4",tunnel_ip="2.3.4.7",user="user1",vdom="root"} 1
+	# HELP fortigate_vpn_user_session_receive_bytes_total Total number of bytes received from the VPN user
+	# TYPE fortigate_vpn_user_session_receive_bytes_total counter
+	fortigate_vpn_user_session_receive_bytes_total{index="620",user="user1",vdom="root"} 1.632413e+06
+	fortigate_vpn_user_session_receive_bytes_total{index="630",user="user2",vdom="root"} 163873
+	fortigate_vpn_user_session_receive_bytes_total{index="640",user="user1",vdom="root"} 16874
+	# HELP fortigate_vpn_user_session_transmit_bytes_total Total number of bytes transmitted to the VPN user
+	# TYPE fortigate_vpn_user_session_transmit_bytes_total counter
+	fortigate_vpn_user_session_transmit_bytes_total{index="620",user="user1",vdom="root"} 9.684145e+06
+	fortigate_vpn_user_session_transmit_bytes_total{index="630",user="user2",vdom="root"} 9.542145e+06
+	fortigate_vpn_user_session_transmit_bytes_total{index="640",user="user1",vdom="root"} 9641
+	# HELP fortigate_vpn_user_sessions_truncated Set to 1 if there were more VPN user sessions than -max-vpn-users and not all are exported
+	# TYPE fortigate_vpn_user_sessions_truncated gauge
+	fortigate_vpn_user_sessions_truncated{vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em),
+		"fortigate_vpn_user_session_info",
+		"fortigate_vpn_user_session_receive_bytes_total",
+		"fortigate_vpn_user_session_transmit_bytes_total",
+		"fortigate_vpn_user_sessions_truncated"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+	if n, err := testutil.GatherAndCount(r, "fortigate_vpn_user_session_duration_seconds"); err != nil || n != 3 {
+		t.Errorf("expected 3 session durations, got %d (err %v)", n, err)
+	}
+}
+
+func TestVPNSslUserDetailsTruncated(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/vpn/ssl", "testdata/vpn.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	flag.Set("max-vpn-users", "2")
+	flag.Set("vpn-user-details", "true")
+	defer flag.Set("vpn-user-details", "false")
+	defer flag.Set("max-vpn-users", "10")
+	config.MustReInit()
+	if !testProbe(probeVPNSsl, c, r) {
+		t.Errorf("probeVPNSsl() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_vpn_connections Number of VPN connections
+	# TYPE fortigate_vpn_connections gauge
+	fortigate_vpn_connections{vdom="root"} 3
+	# HELP fortigate_vpn_user_session_info Information about a VPN user session
+	# TYPE fortigate_vpn_user_session_info gauge
+	fortigate_vpn_user_session_info{index="620",source_ip="1.2.3.4",tunnel_ip="2.3.4.5",user="user1",vdom="root"} 1
+	fortigate_vpn_user_session_info{index="630",source_ip="1.2.3.5",tunnel_ip="2.3.4.6",user="user2",vdom="root"} 1
+	# HELP fortigate_vpn_user_sessions_truncated Set to 1 if there were more VPN user sessions than -max-vpn-users and not all are exported
+	# TYPE fortigate_vpn_user_sessions_truncated gauge
+	fortigate_vpn_user_sessions_truncated{vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em),
+		"fortigate_vpn_connections",
+		"fortigate_vpn_users",
+		"fortigate_vpn_user_session_info",
+		"fortigate_vpn_user_sessions_truncated"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestVPNSslUserDetailsTruncatedByLogin(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/vpn/ssl", "testdata/vpn-slot-reuse.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	flag.Set("max-vpn-users", "2")
+	flag.Set("vpn-user-details", "true")
+	defer flag.Set("vpn-user-details", "false")
+	defer flag.Set("max-vpn-users", "10")
+	config.MustReInit()
+	if !testProbe(probeVPNSsl, c, r) {
+		t.Errorf("probeVPNSsl() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_vpn_connections Number of VPN connections
+	# TYPE fortigate_vpn_connections gauge
+	fortigate_vpn_connections{vdom="root"} 3
+	# HELP fortigate_vpn_user_session_info Information about a VPN user session
+	# TYPE fortigate_vpn_user_session_info gauge
+	fortigate_vpn_user_session_info{index="620",source_ip="1.2.3.4",tunnel_ip="2.3.4.5",user="user1",vdom="root"} 1
+	fortigate_vpn_user_session_info{index="640",source_ip="1.2.3.4",tunnel_ip="2.3.4.7",user="user1",vdom="root"} 1
+	# HELP fortigate_vpn_user_sessions_truncated Set to 1 if there were more VPN user sessions than -max-vpn-users and not all are exported
+	# TYPE fortigate_vpn_user_sessions_truncated gauge
+	fortigate_vpn_user_sessions_truncated{vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em),
+		"fortigate_vpn_connections",
+		"fortigate_vpn_users",
+		"fortigate_vpn_user_session_info",
+		"fortigate_vpn_user_sessions_truncated"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -14,13 +14,11 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeWebUIState(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeWebUIState(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		mRebootTime = prometheus.NewDesc(
 			"fortigate_last_reboot_seconds",
@@ -46,13 +44,12 @@ func probeWebUIState(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metri
 	var state webuiState
 
 	if err := c.Get("api/v2/monitor/web-ui/state", "", &state); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	m := []prometheus.Metric{
 		prometheus.MustNewConstMetric(mRebootTime, prometheus.GaugeValue, state.Results.UTCLastReboot/1000),
 		prometheus.MustNewConstMetric(mSnapshotTime, prometheus.GaugeValue, state.Results.SnapshotUTCTime/1000),
 	}
-	return m, true
+	return m, nil
 }
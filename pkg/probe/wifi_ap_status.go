@@ -14,13 +14,11 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeWifiAPStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeWifiAPStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		wtpCount = prometheus.NewDesc(
 			"fortigate_wifi_access_points",
@@ -53,8 +51,7 @@ func probeWifiAPStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Met
 
 	var response ApiStatusResponse
 	if err := c.Get("api/v2/monitor/wifi/ap_status", "vdom=*", &response); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	var m []prometheus.Metric
@@ -67,5 +64,5 @@ func probeWifiAPStatus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Met
 		m = append(m, prometheus.MustNewConstMetric(wtpMaxClientCount, prometheus.GaugeValue, rs.Results.ClientCountMax, rs.VDOM))
 	}
 
-	return m, true
+	return m, nil
 }
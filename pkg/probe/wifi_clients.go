@@ -14,13 +14,11 @@
 package probe
 
 import (
-	"log"
-
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func probeWifiClients(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+func probeWifiClients(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, error) {
 	var (
 		clientInfo = prometheus.NewDesc(
 			"fortigate_wifi_client_info",
@@ -85,8 +83,7 @@ func probeWifiClients(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metr
 	// Consider implementing pagination to remove this limit of 1000 entries
 	var response ApiWifiClientResponse
 	if err := c.Get("api/v2/monitor/wifi/client", "vdom=*&start=0&count=1000", &response); err != nil {
-		log.Printf("Error: %v", err)
-		return nil, false
+		return nil, err
 	}
 
 	var m []prometheus.Metric
@@ -102,5 +99,5 @@ func probeWifiClients(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metr
 			m = append(m, prometheus.MustNewConstMetric(txRetryPercentage, prometheus.GaugeValue, result.TxRetryPercentage/100, rs.VDOM, result.MAC))
 		}
 	}
-	return m, true
+	return m, nil
 }